@@ -0,0 +1,547 @@
+package design
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/SCKelemen/color"
+)
+
+// Chroma bounds for colors derived from the accent, keeping them visibly
+// colorful without leaving the sRGB gamut too often
+const (
+	minDerivedChroma = 0.08
+	maxDerivedChroma = 0.20
+)
+
+// ColorForLabel deterministically maps a label (username, tag, ...) to a hue
+// and returns a color sharing the accent's lightness and chroma profile,
+// adjusted to be visible (3:1) against the Background. The same label always
+// yields the same color within a theme.
+func (dt *DesignTokens) ColorForLabel(label string) string {
+	h := fnv.New32a()
+	h.Write([]byte(label))
+	hue := float64(h.Sum32()%360000) / 1000
+
+	l, c := 0.65, 0.15
+	if accent, err := parseTokenColor(dt.Accent); err == nil {
+		oklch := color.ToOKLCH(accent)
+		l = oklch.L
+		c = math.Max(minDerivedChroma, math.Min(maxDerivedChroma, oklch.C))
+	}
+
+	labelColor := color.Color(color.NewOKLCH(l, c, hue, 1))
+	if bg, err := parseTokenColor(dt.Background); err == nil {
+		labelColor = adjustForContrast(labelColor, bg, WCAGLargeTextAA)
+	}
+	return colorToHex(labelColor)
+}
+
+// AccentComplementary returns the accent's complementary color (hue +180°)
+func (dt *DesignTokens) AccentComplementary() string {
+	return dt.accentHarmony(180)
+}
+
+// AccentTriadic returns the two triadic companions of the accent (hue +120° and +240°)
+func (dt *DesignTokens) AccentTriadic() (string, string) {
+	return dt.accentHarmony(120), dt.accentHarmony(240)
+}
+
+// AccentAnalogous returns the two analogous companions of the accent,
+// rotated by -degrees and +degrees
+func (dt *DesignTokens) AccentAnalogous(degrees float64) (string, string) {
+	return dt.accentHarmony(-degrees), dt.accentHarmony(degrees)
+}
+
+// accentHarmony rotates the accent hue in OKLCH and keeps the result
+// reasonably saturated and inside the sRGB gamut
+func (dt *DesignTokens) accentHarmony(degrees float64) string {
+	accent, err := parseTokenColor(dt.Accent)
+	if err != nil {
+		return dt.Accent
+	}
+	oklch := color.ToOKLCH(rotateHue(accent, degrees))
+	oklch.C = math.Max(minDerivedChroma, math.Min(maxDerivedChroma, oklch.C))
+	return colorToHex(fitToGamut(oklch))
+}
+
+// minSeriesDistance is the OKLAB distance below which adjacent series colors
+// are hard to tell apart
+const minSeriesDistance = 0.04
+
+// HarmonizedSeries returns n chart series colors sharing the accent's OKLCH
+// lightness and chroma (chroma kept within the derived bounds), with hues
+// spread evenly around the wheel starting at the accent hue. When n is large
+// enough that neighbouring hues become hard to distinguish, every other color
+// alternates lightness so adjacent colors still differ perceptibly.
+func (dt *DesignTokens) HarmonizedSeries(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	l, c, h := 0.65, 0.15, 0.0
+	if accent, err := parseTokenColor(dt.Accent); err == nil {
+		oklch := color.ToOKLCH(accent)
+		l, h = oklch.L, oklch.H
+		c = math.Max(minDerivedChroma, math.Min(maxDerivedChroma, oklch.C))
+	}
+
+	// Alternate lightness away from whichever extreme is closer
+	altL := l - 0.12
+	if l < 0.5 {
+		altL = l + 0.12
+	}
+
+	series := make([]string, n)
+	var prev color.Color
+	for i := range series {
+		hue := math.Mod(h+float64(i)*360/float64(n), 360)
+		candidate := fitToGamut(color.NewOKLCH(l, c, hue, 1))
+		if prev != nil && i%2 == 1 && colorDistance(candidate, prev) < minSeriesDistance {
+			candidate = fitToGamut(color.NewOKLCH(altL, c, hue, 1))
+		}
+		series[i] = colorToHex(candidate)
+		prev = candidate
+	}
+	return series
+}
+
+// Accent gradient companion: hue rotation in degrees and the small OKLCH
+// lightness step toward the mode's contrast direction
+const (
+	gradientHueShift  = 30
+	gradientLightStep = 0.04
+)
+
+// AccentGradientStops returns two harmonious stops for accent gradients: the
+// accent and a companion rotated 30° in hue. The companion keeps the accent's
+// chroma (within the derived bounds) and nearly its lightness — one small
+// step lighter in dark mode, darker in light mode — so the gradient reads at
+// an even brightness. Flat tokens return the accent for both stops.
+func (dt *DesignTokens) AccentGradientStops() (start, end string) {
+	accent, err := parseTokenColor(dt.Accent)
+	if err != nil {
+		return dt.Accent, dt.Accent
+	}
+	if dt.Flat {
+		return colorToHex(accent), colorToHex(accent)
+	}
+	oklch := color.ToOKLCH(rotateHue(accent, gradientHueShift))
+	oklch.C = math.Max(minDerivedChroma, math.Min(maxDerivedChroma, oklch.C))
+	if dt.Mode == "light" {
+		oklch.L = math.Max(0, oklch.L-gradientLightStep)
+	} else {
+		oklch.L = math.Min(1, oklch.L+gradientLightStep)
+	}
+	return colorToHex(accent), colorToHex(fitToGamut(oklch))
+}
+
+// Temperature classifications
+const (
+	TemperatureWarm    = "warm"
+	TemperatureCool    = "cool"
+	TemperatureNeutral = "neutral"
+)
+
+// Temperature boundaries: OKLCH hues in [warmHueEnd, warmHueStart) are cool
+// (yellow-greens through greens, cyans, blues and violets), the rest warm
+// (magentas, reds, oranges, yellows). Below neutralChroma the dominant hue
+// is too weak to classify.
+const (
+	warmHueStart  = 330
+	warmHueEnd    = 115
+	neutralChroma = 0.03
+)
+
+// Temperature classifies the theme as "warm", "cool" or "neutral" from the
+// dominant hue of the Accent and Background: their OKLCH hues are averaged
+// weighted by chroma, so a saturated accent outweighs a near-gray background.
+// Themes whose averaged chroma is below 0.03 are neutral.
+func (dt *DesignTokens) Temperature() string {
+	var a, b float64
+	for _, value := range []string{dt.Accent, dt.Background} {
+		c, err := parseTokenColor(value)
+		if err != nil {
+			continue
+		}
+		oklab := color.ToOKLAB(c)
+		a += oklab.A / 2
+		b += oklab.B / 2
+	}
+
+	if math.Hypot(a, b) < neutralChroma {
+		return TemperatureNeutral
+	}
+	hue := math.Mod(math.Atan2(b, a)*180/math.Pi+360, 360)
+	if hue >= warmHueEnd && hue < warmHueStart {
+		return TemperatureCool
+	}
+	return TemperatureWarm
+}
+
+// Motion energy boundaries: accent OKLCH chroma below mutedAccentChroma is
+// muted; at vividAccentChroma and above, with AA contrast against the
+// background, the theme is energetic
+const (
+	mutedAccentChroma = 0.08
+	vividAccentChroma = 0.18
+)
+
+// SuggestedMotionLevel recommends a MotionTokens level matching the theme's
+// visual energy: "subtle" for muted accents (OKLCH chroma below 0.08),
+// "loud" for vivid accents (0.18 and above) that also reach AA (4.5:1)
+// against the Background, and "regular" otherwise. Pass it as the motion
+// param when the user has not chosen one; it never suggests "none", which is
+// a user preference.
+func (dt *DesignTokens) SuggestedMotionLevel() string {
+	accent, err := parseTokenColor(dt.Accent)
+	if err != nil {
+		return "subtle"
+	}
+	chroma := color.ToOKLCH(accent).C
+	if chroma < mutedAccentChroma {
+		return "subtle"
+	}
+	if bg, err := parseTokenColor(dt.Background); err == nil && chroma >= vividAccentChroma &&
+		contrastRatio(accent, bg) >= contrastThreshold(WCAGNormalTextAA) {
+		return "loud"
+	}
+	return "regular"
+}
+
+// fitToGamut reduces an OKLCH color's chroma until it fits the sRGB gamut,
+// preserving lightness and hue
+func fitToGamut(c *color.OKLCH) color.Color {
+	const tolerance = 0.002
+	candidate := *c
+	for i := 0; i < 50; i++ {
+		// RGBA() clamps to sRGB, so an in-gamut color survives the round trip
+		clipped := color.ToOKLAB(&candidate)
+		rad := candidate.H * math.Pi / 180
+		da := clipped.A - candidate.C*math.Cos(rad)
+		db := clipped.B - candidate.C*math.Sin(rad)
+		dl := clipped.L - candidate.L
+		if math.Sqrt(dl*dl+da*da+db*db) <= tolerance {
+			break
+		}
+		candidate.C *= 0.95
+	}
+	return &candidate
+}
+
+// DeriveAccentFromBrand returns an accent with the brand's hue that meets
+// minContrast against Background. The brand's OKLCH lightness is moved away
+// from the background (chroma is reduced only where needed to stay in sRGB);
+// a brand that already passes is returned as is. It fails if the brand or
+// background cannot be parsed, or if no lightness reaches minContrast.
+func (dt *DesignTokens) DeriveAccentFromBrand(brand string, minContrast float64) (string, error) {
+	b, err := parseTokenColor(brand)
+	if err != nil {
+		return "", err
+	}
+	bg, err := parseTokenColor(dt.Background)
+	if err != nil {
+		return "", err
+	}
+	target := contrastThreshold(minContrast)
+	if contrastRatio(b, bg) >= target {
+		return colorToHex(b), nil
+	}
+
+	lighten := contrastRatio(color.RGB(1, 1, 1), bg) >= contrastRatio(color.RGB(0, 0, 0), bg)
+	oklch := color.ToOKLCH(b)
+	for step := 1; step <= 100; step++ {
+		candidate := *oklch
+		if lighten {
+			candidate.L = oklch.L + (1-oklch.L)*float64(step)/100
+		} else {
+			candidate.L = oklch.L * (1 - float64(step)/100)
+		}
+		if fitted := fitToGamut(&candidate); contrastRatio(fitted, bg) >= target {
+			return colorToHex(fitted), nil
+		}
+	}
+	return "", fmt.Errorf("brand %s cannot reach %.2f:1 contrast against %s", brand, minContrast, dt.Background)
+}
+
+// OverlayAccent returns the accent adjusted for drawing over imagery with the
+// given mix-blend-mode, along with the blend mode to pair it with.
+// Multiply darkens what is beneath, so the accent is lightened; screen
+// lightens, so the accent is darkened; overlay pushes the accent toward
+// mid-lightness and boosts chroma. Unknown modes return the accent with "normal".
+func (dt *DesignTokens) OverlayAccent(mode string) (hex string, blend string) {
+	return overlayAccent(dt.Accent, mode)
+}
+
+// overlayAccent adjusts an accent color for a mix-blend-mode
+func overlayAccent(accentValue, mode string) (string, string) {
+	accent, err := parseTokenColor(accentValue)
+	if err != nil {
+		return accentValue, "normal"
+	}
+
+	oklch := color.ToOKLCH(accent)
+	switch mode {
+	case "multiply":
+		oklch.L += (1 - oklch.L) * 0.35
+	case "screen":
+		oklch.L *= 0.7
+	case "overlay":
+		oklch.L = 0.6 + (oklch.L-0.6)*0.5
+		oklch.C = math.Min(maxDerivedChroma*1.2, oklch.C*1.15)
+	default:
+		return accentValue, "normal"
+	}
+	return colorToHex(fitToGamut(oklch)), mode
+}
+
+// AccentOnSurface returns an accent variant legible as text or icon color on
+// the Background (AA, 4.5:1): light accents are darkened on light surfaces and
+// dark accents lightened on dark ones, preserving hue. Unlike a foreground for
+// text drawn on an accent fill, here the accent itself is the foreground.
+func (dt *DesignTokens) AccentOnSurface() string {
+	accent, err := parseTokenColor(dt.Accent)
+	if err != nil {
+		return dt.Accent
+	}
+	bg, err := parseTokenColor(dt.Background)
+	if err != nil {
+		return dt.Accent
+	}
+	return colorToHex(adjustForContrast(accent, bg, WCAGNormalTextAA))
+}
+
+// SelectionColors returns the ::selection background and text colors. The
+// background defaults to the accent and the text to its best foreground
+// (white or black); a derived background is adjusted in lightness until the
+// text reaches AA (4.5:1) on it. Explicit SelectionBackground and
+// SelectionColor overrides are used as given.
+func (dt *DesignTokens) SelectionColors() (background, foreground string) {
+	background, foreground = dt.SelectionBackground, dt.SelectionColor
+	derivedBg := background == ""
+	if derivedBg {
+		background = dt.Accent
+	}
+	if foreground == "" {
+		foreground = bestForeground(background)
+	}
+
+	if derivedBg {
+		bg, errBg := parseTokenColor(background)
+		fg, errFg := parseTokenColor(foreground)
+		if errBg == nil && errFg == nil && contrastRatio(fg, bg) < contrastThreshold(WCAGNormalTextAA) {
+			background = colorToHex(adjustForContrast(bg, fg, WCAGNormalTextAA))
+		}
+	}
+	return background, foreground
+}
+
+// Link color derivation: the OKLAB distance a link must keep from body text
+// to be identifiable without an underline, the fallback link (an OKLCH blue,
+// lighter on dark backgrounds) used when the accent is gray or too close to
+// the text, and the hue rotation toward purple for visited links
+const (
+	minLinkTextDistance        = 0.15
+	fallbackLinkHue            = 260
+	fallbackLinkChroma         = 0.15
+	fallbackLinkLightnessDark  = 0.72
+	fallbackLinkLightnessLight = 0.5
+	visitedLinkHueShift        = 50
+)
+
+// LinkColors returns the hyperlink and visited-link colors. The link is the
+// Link override, else the accent; if it is gray or perceptually too close to
+// Color it is replaced by a blue. Both colors are adjusted in lightness to reach AA
+// (4.5:1) against the Background; the visited color is the link rotated
+// toward purple.
+func (dt *DesignTokens) LinkColors() (link, visited string) {
+	link = dt.Link
+	if link == "" {
+		link = dt.Accent
+	}
+	c, err := parseTokenColor(link)
+	if err != nil {
+		return link, link
+	}
+	bg, err := parseTokenColor(dt.Background)
+	if err != nil {
+		return colorToHex(c), colorToHex(rotateHue(c, visitedLinkHueShift))
+	}
+
+	text, err := parseTokenColor(dt.Color)
+	if color.ToOKLCH(c).C < pairAchromaticLimit || (err == nil && colorDistance(c, text) < minLinkTextDistance) {
+		l := fallbackLinkLightnessDark
+		if dt.Luminosity() > pairMidGray {
+			l = fallbackLinkLightnessLight
+		}
+		c = fitToGamut(color.NewOKLCH(l, fallbackLinkChroma, fallbackLinkHue, 1))
+	}
+	c = adjustForContrast(c, bg, WCAGNormalTextAA)
+	v := adjustForContrast(rotateHue(c, visitedLinkHueShift), bg, WCAGNormalTextAA)
+	return colorToHex(c), colorToHex(v)
+}
+
+// Disabled text on accent fills: how far the accent foreground is mixed
+// (OKLAB) into the accent, and the contrast floor it must keep so the label
+// stays faintly legible
+const (
+	disabledAccentTextMix      = 0.55
+	disabledAccentTextMinRatio = 1.6
+	disabledAccentTextStep     = 0.05
+)
+
+// DisabledAccentText returns the text color for disabled controls that keep
+// their accent fill: the accent foreground (white or black) mixed 55% into
+// the accent, backed off as needed to keep at least 1.6:1 against it
+func (dt *DesignTokens) DisabledAccentText() string {
+	fg := bestForeground(dt.Accent)
+	accent, err := parseTokenColor(dt.Accent)
+	if err != nil {
+		return fg
+	}
+
+	for mix := disabledAccentTextMix; mix > 0; mix -= disabledAccentTextStep {
+		candidate := mixColors(fg, dt.Accent, mix)
+		if c, err := parseTokenColor(candidate); err == nil &&
+			contrastRatio(c, accent) >= contrastThreshold(disabledAccentTextMinRatio) {
+			return candidate
+		}
+	}
+	return fg
+}
+
+// AccentTextColorBothModes returns the best text color (white or black) for
+// accent-filled elements in light and in dark mode, using AccentLight and
+// AccentDark when set and the Accent otherwise
+func (dt *DesignTokens) AccentTextColorBothModes() (light, dark string) {
+	lightAccent, darkAccent := dt.Accent, dt.Accent
+	if dt.AccentLight != "" {
+		lightAccent = dt.AccentLight
+	}
+	if dt.AccentDark != "" {
+		darkAccent = dt.AccentDark
+	}
+	return bestForeground(lightAccent), bestForeground(darkAccent)
+}
+
+// CapAccentSaturation returns a copy of the tokens with the accent's OKLCH
+// chroma (and that of its light/dark variants) clamped to maxChroma,
+// preserving hue and lightness. Useful for muted or pastel themes.
+func (dt *DesignTokens) CapAccentSaturation(maxChroma float64) *DesignTokens {
+	capped := dt.clone()
+	for _, field := range []*string{&capped.Accent, &capped.AccentLight, &capped.AccentDark} {
+		if c, err := parseTokenColor(*field); err == nil {
+			*field = colorToHex(capChroma(c, maxChroma))
+		}
+	}
+	return capped
+}
+
+// SetAccentLightness sets the OKLCH lightness of the accent (and of its
+// light/dark variants) to l, clamped to [0, 1], keeping hue and chroma except
+// where chroma must drop to stay in sRGB. Unlike lighten/darken this is
+// absolute: accent_l=0.65 yields the same tone whatever the theme's accent.
+func (dt *DesignTokens) SetAccentLightness(l float64) {
+	l = math.Max(0, math.Min(1, l))
+	for _, field := range []*string{&dt.Accent, &dt.AccentLight, &dt.AccentDark} {
+		if c, err := parseTokenColor(*field); err == nil {
+			oklch := color.ToOKLCH(c)
+			oklch.L = l
+			*field = colorToHex(fitToGamut(oklch))
+		}
+	}
+}
+
+// achromaticAccentHue is the OKLCH hue (blue) given to a gray accent, which
+// has no hue of its own, when EnsureAccentSaturation adds chroma
+const achromaticAccentHue = 260
+
+// EnsureAccentSaturation returns a copy of the tokens with the accent's OKLCH
+// chroma (and that of its light/dark variants) raised to at least minChroma,
+// preserving hue and lightness; chroma stops short only where sRGB cannot
+// hold it. Vivid accents are left alone; pure grays take a blue hue.
+func (dt *DesignTokens) EnsureAccentSaturation(minChroma float64) *DesignTokens {
+	boosted := dt.clone()
+	for _, field := range []*string{&boosted.Accent, &boosted.AccentLight, &boosted.AccentDark} {
+		c, err := parseTokenColor(*field)
+		if err != nil {
+			continue
+		}
+		oklch := color.ToOKLCH(c)
+		if oklch.C >= minChroma {
+			continue
+		}
+		if oklch.C < 1e-4 {
+			oklch.H = achromaticAccentHue
+		}
+		oklch.C = minChroma
+		*field = colorToHex(fitToGamut(oklch))
+	}
+	return boosted
+}
+
+// capChroma clamps a color's OKLCH chroma to maxChroma
+func capChroma(c color.Color, maxChroma float64) color.Color {
+	oklch := color.ToOKLCH(c)
+	if oklch.C <= maxChroma {
+		return c
+	}
+	oklch.C = math.Max(0, maxChroma)
+	return oklch
+}
+
+// accentStateStep is the OKLCH lightness change per interaction state
+const accentStateStep = 0.06
+
+// AccentHover returns the hover-state accent: one step darker in light mode,
+// one step lighter in dark mode. If that would make the accent's foreground
+// (bestForeground) less legible than the accent itself (capped at AA, 4.5:1),
+// hover and active both step the other way instead.
+func (dt *DesignTokens) AccentHover() string {
+	return dt.accentState(1)
+}
+
+// AccentActive returns the active/pressed accent, one step beyond AccentHover
+func (dt *DesignTokens) AccentActive() string {
+	return dt.accentState(2)
+}
+
+// accentState shifts the accent lightness by steps interaction-state steps
+func (dt *DesignTokens) accentState(steps int) string {
+	accent, err := parseTokenColor(dt.Accent)
+	if err != nil {
+		return dt.Accent
+	}
+	return colorToHex(shiftLightness(accent, dt.accentStateDirection()*accentStateStep*float64(steps)))
+}
+
+// accentStateDirection returns +1 if interaction states lighten the accent
+// and -1 if they darken it. States shift the conventional way for the mode,
+// or the opposite way if the furthest state (active) would make the
+// foreground less legible than required, so hover and active always move in
+// the same direction.
+func (dt *DesignTokens) accentStateDirection() float64 {
+	direction := 1.0
+	if dt.Mode == "light" {
+		direction = -1.0
+	}
+	accent, err := parseTokenColor(dt.Accent)
+	if err != nil {
+		return direction
+	}
+	fg, _ := parseTokenColor(bestForeground(dt.Accent))
+
+	minRatio := math.Min(contrastThreshold(WCAGNormalTextAA), contrastRatio(fg, accent))
+	if contrastRatio(fg, shiftLightness(accent, direction*accentStateStep*2)) < minRatio {
+		direction = -direction
+	}
+	return direction
+}
+
+// shiftLightness moves a color's OKLCH lightness by d, clamped to [0, 1]
+func shiftLightness(c color.Color, d float64) color.Color {
+	oklch := color.ToOKLCH(c)
+	oklch.L = math.Max(0, math.Min(1, oklch.L+d))
+	return oklch
+}
@@ -0,0 +1,75 @@
+package design
+
+import "github.com/SCKelemen/color"
+
+// Dark mode synthesis parameters
+const (
+	autoDarkAccentLift = 0.08 // OKLCH lightness added to dark-mode accents
+	autoDarkMinL       = 0.18 // Floor for synthesized background lightness
+)
+
+// AutoDark returns a dark-mode copy of the tokens. Missing dark variants are
+// synthesized from the current (light) colors: Background and Color have their
+// lightness inverted while preserving hue, Color is then adjusted to keep AA
+// contrast on the new background, and the Accent is slightly brightened.
+// Existing dark variants are kept as-is.
+func (dt *DesignTokens) AutoDark() *DesignTokens {
+	darkTokens := *dt // Copy struct
+
+	// Light variants default to the current colors so LightMode() round-trips
+	if dt.Mode != "dark" {
+		if darkTokens.ColorLight == "" {
+			darkTokens.ColorLight = dt.Color
+		}
+		if darkTokens.BackgroundLight == "" {
+			darkTokens.BackgroundLight = dt.Background
+		}
+		if darkTokens.AccentLight == "" {
+			darkTokens.AccentLight = dt.Accent
+		}
+	}
+
+	var bg color.Color
+	if dt.BackgroundDark == "" {
+		if base, err := parseTokenColor(dt.Background); err == nil {
+			oklch := color.ToOKLCH(base)
+			if dt.Mode != "dark" {
+				oklch.L = 1 - oklch.L
+				if oklch.L < autoDarkMinL {
+					oklch.L = autoDarkMinL
+				}
+			}
+			bg = oklch
+			darkTokens.BackgroundDark = colorToHex(bg)
+		} else {
+			darkTokens.BackgroundDark = dt.Background
+		}
+	} else if parsed, err := parseTokenColor(dt.BackgroundDark); err == nil {
+		bg = parsed
+	}
+
+	if dt.ColorDark == "" {
+		darkTokens.ColorDark = dt.Color
+		if fg, err := parseTokenColor(dt.Color); err == nil && bg != nil {
+			oklch := color.ToOKLCH(fg)
+			if dt.Mode != "dark" {
+				oklch.L = 1 - oklch.L
+			}
+			darkTokens.ColorDark = colorToHex(adjustForContrast(oklch, bg, WCAGNormalTextAA))
+		}
+	}
+
+	if dt.AccentDark == "" {
+		darkTokens.AccentDark = dt.Accent
+		if accent, err := parseTokenColor(dt.Accent); err == nil && dt.Mode != "dark" {
+			oklch := color.ToOKLCH(accent)
+			oklch.L += autoDarkAccentLift
+			if oklch.L > 1 {
+				oklch.L = 1
+			}
+			darkTokens.AccentDark = colorToHex(oklch)
+		}
+	}
+
+	return darkTokens.DarkMode()
+}
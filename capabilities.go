@@ -0,0 +1,32 @@
+package design
+
+// ThemeCapabilities describes what a theme defines, so UIs can enable only
+// the controls that apply (e.g. hide the light toggle for a dark-only theme)
+type ThemeCapabilities struct {
+	SupportsLight bool // Can be shown in light mode
+	SupportsDark  bool // Can be shown in dark mode
+	HasBrand      bool // Defines a brand color distinct from the accent
+	HasSeries     bool // HarmonizedSeries derives chart colors from the accent
+	HasGradient   bool // AccentGradientStops yields two distinct stops (not flat)
+
+	// Defines its own success, warning or danger color (see BadgeColors)
+	HasSemanticColors bool
+}
+
+// Capabilities reports what the tokens' theme defines. Mode support follows
+// SupportsMode: the theme definition for built-in and registered themes, or
+// the light/dark variant colors otherwise. Series and gradients are derived
+// from the accent, so they need a parseable one.
+func (dt *DesignTokens) Capabilities() ThemeCapabilities {
+	_, accentErr := parseTokenColor(dt.Accent)
+	start, end := dt.AccentGradientStops()
+	return ThemeCapabilities{
+		SupportsLight: dt.SupportsMode("light"),
+		SupportsDark:  dt.SupportsMode("dark"),
+		HasBrand:      dt.Brand != "" || dt.BrandLight != "" || dt.BrandDark != "",
+		HasSeries:     accentErr == nil,
+		HasGradient:   accentErr == nil && start != end,
+
+		HasSemanticColors: dt.Success != "" || dt.Warning != "" || dt.Danger != "",
+	}
+}
@@ -0,0 +1,239 @@
+package design
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/SCKelemen/color"
+)
+
+// parseTokenColor parses a resolved token color (hex with or without #,
+// or any CSS color syntax supported by the color package)
+func parseTokenColor(value string) (color.Color, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("empty color")
+	}
+	if c, err := color.ParseColor(value); err == nil {
+		return c, nil
+	}
+	if !strings.HasPrefix(value, "#") {
+		if c, err := color.ParseColor("#" + value); err == nil {
+			return c, nil
+		}
+	}
+	if isColorFunction(value) {
+		if c, ok := approximateColorFunction(value); ok {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid color %q", value)
+}
+
+// approximateColorFunction reads the channels of a color() value in a color
+// space the color package does not support as sRGB, so derived-color math
+// still has something to work with. The original string is kept for output.
+func approximateColorFunction(value string) (color.Color, bool) {
+	inner := strings.TrimSpace(value)
+	inner = inner[strings.Index(inner, "(")+1 : len(inner)-1]
+
+	alpha := 1.0
+	if i := strings.Index(inner, "/"); i >= 0 {
+		a, ok := parseColorChannel(strings.TrimSpace(inner[i+1:]))
+		if !ok {
+			return nil, false
+		}
+		alpha = a
+		inner = inner[:i]
+	}
+
+	fields := strings.Fields(inner)
+	if len(fields) != 4 {
+		return nil, false
+	}
+	var ch [3]float64
+	for i, f := range fields[1:] {
+		v, ok := parseColorChannel(f)
+		if !ok {
+			return nil, false
+		}
+		ch[i] = v
+	}
+	return color.NewRGBA(ch[0], ch[1], ch[2], alpha), true
+}
+
+// parseColorChannel parses a 0-1 number or a percentage, clamped to [0, 1]
+func parseColorChannel(s string) (float64, bool) {
+	scale := 1.0
+	if strings.HasSuffix(s, "%") {
+		s = strings.TrimSuffix(s, "%")
+		scale = 100
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return math.Max(0, math.Min(1, v/scale)), true
+}
+
+// colorToHex formats a color as uppercase #RRGGBB (or #RRGGBBAA when
+// translucent), rounding channels rather than truncating them
+func colorToHex(c color.Color) string {
+	r, g, b, a := c.RGBA()
+	hex := fmt.Sprintf("#%02X%02X%02X", channelByte(r), channelByte(g), channelByte(b))
+	if a < 1.0 {
+		hex += fmt.Sprintf("%02X", channelByte(a))
+	}
+	return hex
+}
+
+// channelByte converts a [0, 1] channel value to a rounded 0-255 byte
+func channelByte(v float64) uint8 {
+	if v <= 0 || math.IsNaN(v) {
+		return 0
+	}
+	if v >= 1 {
+		return 255
+	}
+	return uint8(math.Round(v * 255))
+}
+
+// withAlpha returns the color string with its alpha channel replaced,
+// leaving unparseable values untouched
+func withAlpha(value string, alpha float64) string {
+	c, err := parseTokenColor(value)
+	if err != nil {
+		return value
+	}
+	return colorToHex(c.WithAlpha(alpha))
+}
+
+// colorFields returns pointers to every color-valued field of the tokens,
+// so transforms can be applied uniformly to base colors and variants.
+// Brand colors are deliberately excluded: they are never adjusted.
+func (dt *DesignTokens) colorFields() []*string {
+	return []*string{
+		&dt.Color, &dt.Background, &dt.Accent,
+		&dt.ColorLight, &dt.ColorDark,
+		&dt.BackgroundLight, &dt.BackgroundDark,
+		&dt.AccentLight, &dt.AccentDark,
+	}
+}
+
+// mapColors applies fn to every non-empty, parseable color field
+func (dt *DesignTokens) mapColors(fn func(color.Color) color.Color) {
+	for _, field := range dt.colorFields() {
+		if *field == "" {
+			continue
+		}
+		if c, err := parseTokenColor(*field); err == nil {
+			*field = colorToHex(fn(c))
+		}
+	}
+}
+
+// NormalizeHexCase canonicalizes a hex color to uppercase #RRGGBB (or
+// #RRGGBBAA), expanding the #RGB and #RGBA shorthands, so equal colors always
+// produce identical strings. Anything that is not a hex color (named colors,
+// rgb(), ...) is returned unchanged.
+func NormalizeHexCase(s string) string {
+	if !strings.HasPrefix(s, "#") {
+		return s
+	}
+	digits := s[1:]
+	for _, r := range digits {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return s
+		}
+	}
+	switch len(digits) {
+	case 3, 4:
+		var b strings.Builder
+		b.WriteByte('#')
+		for _, r := range digits {
+			b.WriteRune(r)
+			b.WriteRune(r)
+		}
+		return strings.ToUpper(b.String())
+	case 6, 8:
+		return strings.ToUpper(s)
+	}
+	return s
+}
+
+// mixColors blends two token colors in OKLAB space; weight 0 returns a, 1 returns b.
+// If either color cannot be parsed, a is returned unchanged.
+func mixColors(a, b string, weight float64) string {
+	ca, err := parseTokenColor(a)
+	if err != nil {
+		return a
+	}
+	cb, err := parseTokenColor(b)
+	if err != nil {
+		return a
+	}
+	return colorToHex(color.MixInSpace(ca, cb, weight, color.GradientOKLAB))
+}
+
+// MixColors blends any number of colors by weight in OKLAB space, the same
+// space mixColors uses. Weights are normalized, so {3, 1} and {0.75, 0.25} are
+// equivalent; they must match colors in length, be non-negative and not all
+// zero.
+func MixColors(colors []string, weights []float64) (string, error) {
+	if len(colors) == 0 {
+		return "", fmt.Errorf("no colors to mix")
+	}
+	if len(weights) != len(colors) {
+		return "", fmt.Errorf("got %d weights for %d colors", len(weights), len(colors))
+	}
+
+	var total float64
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) {
+			return "", fmt.Errorf("invalid weight %d: %g (must be non-negative)", i, w)
+		}
+		total += w
+	}
+	if total == 0 {
+		return "", fmt.Errorf("weights sum to zero")
+	}
+
+	var l, a, b, alpha float64
+	for i, value := range colors {
+		c, err := parseTokenColor(value)
+		if err != nil {
+			return "", err
+		}
+		lab := color.ToOKLAB(c)
+		w := weights[i] / total
+		l += lab.L * w
+		a += lab.A * w
+		b += lab.B * w
+		alpha += c.Alpha() * w
+	}
+	return colorToHex(color.NewOKLAB(l, a, b, alpha)), nil
+}
+
+// bestForeground returns white or black, whichever contrasts more with bg
+func bestForeground(bg string) string {
+	const white, black = "#FFFFFF", "#000000"
+	onWhite, err := ContrastRatio(white, bg)
+	if err != nil {
+		return white
+	}
+	onBlack, _ := ContrastRatio(black, bg)
+	if onBlack > onWhite {
+		return black
+	}
+	return white
+}
+
+// colorDistance returns the perceptual (OKLAB Euclidean) distance between two
+// colors; ~0.02 is a just-noticeable difference
+func colorDistance(a, b color.Color) float64 {
+	la, lb := color.ToOKLAB(a), color.ToOKLAB(b)
+	dl, da, db := la.L-lb.L, la.A-lb.A, la.B-lb.B
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
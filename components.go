@@ -0,0 +1,149 @@
+package design
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ComponentCSS returns a CSS block scoped to a component class (".card",
+// ".button", ".badge", ".input") mapping the tokens to the component's roles:
+// background, foreground, border, radius and padding. Cards and inputs sit on
+// the surface, buttons use the accent as fill, and badges use an accent tint.
+// Unknown components return an empty string.
+func (dt *DesignTokens) ComponentCSS(component string) string {
+	var vars []cssVariable
+	px := func(v int) string { return fmt.Sprintf("%dpx", v) }
+	// Buttons and inputs use half the radius; a full radius stays a pill
+	halfRadius := px(dt.Radius / 2)
+	if dt.IsRadiusFull() {
+		halfRadius = dt.cssRadius()
+	}
+
+	switch component {
+	case "card":
+		vars = []cssVariable{
+			{"background", dt.Background},
+			{"foreground", dt.Color},
+			{"border", mixColors(dt.Background, dt.Color, 0.12)},
+			{"radius", dt.cssRadius()},
+			{"padding", px(dt.Padding)},
+		}
+	case "button":
+		vars = []cssVariable{
+			{"background", dt.Accent},
+			{"foreground", bestForeground(dt.Accent)},
+			{"border", dt.Accent},
+			{"radius", halfRadius},
+			{"padding", px(dt.Padding/2) + " " + px(dt.Padding)},
+		}
+	case "badge":
+		bg := mixColors(dt.Background, dt.Accent, 0.18)
+		fg := dt.Accent
+		if base, err := parseTokenColor(bg); err == nil {
+			if accent, err := parseTokenColor(dt.Accent); err == nil {
+				fg = colorToHex(adjustForContrast(accent, base, WCAGNormalTextAA))
+			}
+		}
+		vars = []cssVariable{
+			{"background", bg},
+			{"foreground", fg},
+			{"border", mixColors(dt.Background, dt.Accent, 0.35)},
+			{"radius", "9999px"},
+			{"padding", px(dt.Padding/4) + " " + px(dt.Padding/2)},
+		}
+	case "input":
+		vars = []cssVariable{
+			{"background", dt.Background},
+			{"foreground", dt.Color},
+			{"border", mixColors(dt.Background, dt.Color, 0.25)},
+			{"radius", halfRadius},
+			{"padding", px(dt.Padding/2) + " " + px(dt.Padding*3/4)},
+		}
+	default:
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n\t\t.%s {\n", component)
+	for _, v := range vars {
+		fmt.Fprintf(&b, "\t\t\t--%s-%s: %s;\n", component, v.Name, v.Value)
+	}
+	b.WriteString("\t\t}\n\t")
+	return b.String()
+}
+
+// badgeSemanticColors are the base colors of the status badge kinds;
+// "neutral" uses the text Color
+var badgeSemanticColors = map[string]string{
+	"success": "#16A34A",
+	"warning": "#D97706",
+	"danger":  "#DC2626",
+	"info":    "#2563EB",
+}
+
+// semanticColor returns the tokens' override for a semantic, or ""
+func (dt *DesignTokens) semanticColor(semantic string) string {
+	switch semantic {
+	case "success":
+		return dt.Success
+	case "warning":
+		return dt.Warning
+	case "danger":
+		return dt.Danger
+	}
+	return ""
+}
+
+// Badge tint: initial and maximum OKLAB mix of the badge color into the
+// surface, the mix step, and the contrast the tint must reach against the
+// surface to read as a distinct shape
+const (
+	badgeTintMix         = 0.18
+	badgeTintMaxMix      = 0.5
+	badgeTintStep        = 0.04
+	badgeSurfaceContrast = 1.25
+)
+
+// BadgeColors returns a status badge background and text color for semantic
+// "success", "warning", "danger", "info" or "neutral". The semantic color is
+// the tokens' Success, Warning or Danger when set, else the built-in palette.
+// The background is the semantic color tinted into the card surface (the
+// Background), strengthened
+// until it stands out from the surface; the text is the semantic color
+// adjusted to reach AA (4.5:1) on the badge. Unknown semantics return empty
+// strings.
+func (dt *DesignTokens) BadgeColors(semantic string) (bg, fg string) {
+	base, ok := badgeSemanticColors[semantic]
+	if override := dt.semanticColor(semantic); override != "" {
+		base = override
+	}
+	if semantic == "neutral" {
+		base, ok = dt.Color, true
+	}
+	if !ok {
+		return "", ""
+	}
+
+	surface, err := parseTokenColor(dt.Background)
+	if err != nil {
+		return base, bestForeground(base)
+	}
+	c, err := parseTokenColor(base)
+	if err != nil {
+		return base, bestForeground(base)
+	}
+
+	bg = mixColors(dt.Background, base, badgeTintMix)
+	for mix := badgeTintMix + badgeTintStep; mix <= badgeTintMaxMix; mix += badgeTintStep {
+		if tint, err := parseTokenColor(bg); err == nil && contrastRatio(tint, surface) >= contrastThreshold(badgeSurfaceContrast) {
+			break
+		}
+		bg = mixColors(dt.Background, base, mix)
+	}
+
+	tint, err := parseTokenColor(bg)
+	if err != nil {
+		return bg, bestForeground(bg)
+	}
+	return bg, colorToHex(adjustForContrast(c, tint, WCAGNormalTextAA))
+}
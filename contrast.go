@@ -0,0 +1,334 @@
+package design
+
+import (
+	"math"
+
+	"github.com/SCKelemen/color"
+)
+
+// WCAG 2.1 contrast thresholds
+const (
+	WCAGNormalTextAA = 4.5 // Minimum contrast for normal text
+	WCAGLargeTextAA  = 3.0 // Minimum contrast for large text (18pt, or 14pt bold)
+
+	WCAGNormalTextAAA = 7.0 // Enhanced contrast for normal text
+)
+
+// Contrast algorithms for SetContrastAlgorithm
+const (
+	ContrastWCAG21 = "wcag21" // WCAG 2.1 contrast ratio (default)
+	ContrastAPCA   = "apca"   // APCA lightness contrast (WCAG 3 draft)
+)
+
+// APCA Lc thresholds used in place of the WCAG 2.1 ratios when the APCA
+// algorithm is selected
+const (
+	APCALargeText    = 45.0 // Large text, like WCAG 3:1
+	APCANormalText   = 60.0 // Normal text, like WCAG 4.5:1
+	APCAEnhancedText = 75.0 // Body text, like WCAG 7:1
+)
+
+// apcaEquivalents maps WCAG 2.1 ratios to APCA Lc values; thresholds in
+// between are interpolated linearly
+var apcaEquivalents = [][2]float64{
+	{1, 0},
+	{WCAGLargeTextAA, APCALargeText},
+	{WCAGNormalTextAA, APCANormalText},
+	{WCAGNormalTextAAA, APCAEnhancedText},
+	{21, 106},
+}
+
+// contrastThreshold converts a WCAG 2.1 ratio threshold to the scale of the
+// active contrast algorithm
+func contrastThreshold(ratio float64) float64 {
+	if currentContrastAlgorithm() != ContrastAPCA {
+		return ratio
+	}
+	if ratio <= apcaEquivalents[0][0] {
+		return apcaEquivalents[0][1]
+	}
+	for i := 1; i < len(apcaEquivalents); i++ {
+		lo, hi := apcaEquivalents[i-1], apcaEquivalents[i]
+		if ratio <= hi[0] {
+			return lo[1] + (ratio-lo[0])/(hi[0]-lo[0])*(hi[1]-lo[1])
+		}
+	}
+	return apcaEquivalents[len(apcaEquivalents)-1][1]
+}
+
+// MeetsWCAG reports whether foreground text on background meets a conformance
+// level: "AA" (4.5:1) or "AAA" (7:1), or with the APCA algorithm Lc 60 and
+// Lc 75. Unknown levels and unparseable colors fail.
+func MeetsWCAG(foreground, background, level string) bool {
+	var min float64
+	switch level {
+	case "AA":
+		min = WCAGNormalTextAA
+	case "AAA":
+		min = WCAGNormalTextAAA
+	default:
+		return false
+	}
+	ratio, err := ContrastRatio(foreground, background)
+	return err == nil && ratio >= contrastThreshold(min)
+}
+
+// ContrastRatio returns the contrast between foreground and background under
+// the active algorithm: the WCAG 2.1 ratio from 1 (no contrast) to 21 (black
+// on white), or with APCA the absolute Lc from 0 to about 106
+func ContrastRatio(foreground, background string) (float64, error) {
+	fg, err := parseTokenColor(foreground)
+	if err != nil {
+		return 0, err
+	}
+	bg, err := parseTokenColor(background)
+	if err != nil {
+		return 0, err
+	}
+	return contrastRatio(fg, bg), nil
+}
+
+// contrastRatio computes the contrast of foreground a on background b under
+// the active algorithm (see ContrastRatio)
+func contrastRatio(a, b color.Color) float64 {
+	if currentContrastAlgorithm() == ContrastAPCA {
+		return math.Abs(apcaContrast(a, b))
+	}
+	return wcagContrastRatio(a, b)
+}
+
+// wcagContrastRatio computes the WCAG 2.1 contrast ratio between parsed colors
+func wcagContrastRatio(a, b color.Color) float64 {
+	la := relativeLuminance(a)
+	lb := relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// APCA-W3 0.0.98G constants
+const (
+	apcaBlackThreshold = 0.022
+	apcaBlackClamp     = 1.414
+	apcaDeltaYMin      = 0.0005
+	apcaNormBG         = 0.56
+	apcaNormText       = 0.57
+	apcaRevBG          = 0.65
+	apcaRevText        = 0.62
+	apcaScale          = 1.14
+	apcaLowOffset      = 0.027
+	apcaLowClip        = 0.1
+)
+
+// apcaContrast returns the APCA lightness contrast (Lc) of text on bg:
+// positive for dark text on light backgrounds, negative for light on dark
+func apcaContrast(text, bg color.Color) float64 {
+	yText, yBg := apcaLuminance(text), apcaLuminance(bg)
+	if math.Abs(yBg-yText) < apcaDeltaYMin {
+		return 0
+	}
+
+	var lc float64
+	if yBg > yText {
+		sapc := (math.Pow(yBg, apcaNormBG) - math.Pow(yText, apcaNormText)) * apcaScale
+		if sapc >= apcaLowClip {
+			lc = sapc - apcaLowOffset
+		}
+	} else {
+		sapc := (math.Pow(yBg, apcaRevBG) - math.Pow(yText, apcaRevText)) * apcaScale
+		if sapc <= -apcaLowClip {
+			lc = sapc + apcaLowOffset
+		}
+	}
+	return lc * 100
+}
+
+// apcaLuminance returns the APCA screen luminance of a color, with the soft
+// clamp APCA applies near black
+func apcaLuminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	y := 0.2126729*math.Pow(r, 2.4) + 0.7151522*math.Pow(g, 2.4) + 0.0721750*math.Pow(b, 2.4)
+	if y < apcaBlackThreshold {
+		y += math.Pow(apcaBlackThreshold-y, apcaBlackClamp)
+	}
+	return y
+}
+
+// Suggested minimum font sizes in px by text contrast. WCAG counts 18pt
+// (24px) text as large, which needs only 3:1; below 3:1 no size passes, so
+// the floor is raised further as a best effort.
+const (
+	minFontSizeEnhanced = 12 // >= 7:1 (AAA)
+	minFontSizeNormal   = 14 // >= 4.5:1 (AA normal text)
+	minFontSizeLarge    = 24 // >= 3:1 (AA large text)
+	minFontSizeFailing  = 32 // < 3:1
+)
+
+// MinReadableFontSize suggests the smallest font size in px that keeps text
+// legible at the Color/Background contrast: lower contrast requires larger
+// text. Unparseable colors get the normal-text size.
+func (dt *DesignTokens) MinReadableFontSize() int {
+	ratio, err := ContrastRatio(dt.Color, dt.Background)
+	switch {
+	case err != nil:
+		return minFontSizeNormal
+	case ratio >= contrastThreshold(WCAGNormalTextAAA):
+		return minFontSizeEnhanced
+	case ratio >= contrastThreshold(WCAGNormalTextAA):
+		return minFontSizeNormal
+	case ratio >= contrastThreshold(WCAGLargeTextAA):
+		return minFontSizeLarge
+	}
+	return minFontSizeFailing
+}
+
+// Luminosity returns how light the theme feels: the perceived (OKLAB)
+// lightness of the Background, from 0 (black) to 1 (white). Useful for
+// sorting theme galleries. An unparseable background counts as 0 in dark mode
+// and 1 otherwise.
+func (dt *DesignTokens) Luminosity() float64 {
+	bg, err := parseTokenColor(dt.Background)
+	if err != nil {
+		if dt.Mode == "dark" {
+			return 0
+		}
+		return 1
+	}
+	return math.Max(0, math.Min(1, color.ToOKLAB(bg).L))
+}
+
+// relativeLuminance returns the WCAG relative luminance of a color
+func relativeLuminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	return 0.2126*srgbToLinear(r) + 0.7152*srgbToLinear(g) + 0.0722*srgbToLinear(b)
+}
+
+// srgbToLinear converts a gamma-encoded sRGB channel in [0, 1] to linear light
+func srgbToLinear(v float64) float64 {
+	if v <= 0.03928 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// ContrastPair is the contrast evaluation of one foreground/background pair
+type ContrastPair struct {
+	Name         string // "color/background", "accent/background"
+	Foreground   string
+	Background   string
+	Ratio        float64 // WCAG ratio, or APCA Lc (see SetContrastAlgorithm)
+	PassesNormal bool    // Meets 4.5:1 for normal text
+	PassesLarge  bool    // Meets 3:1 for large text
+}
+
+// ContrastReport summarizes the WCAG contrast compliance of a theme
+type ContrastReport struct {
+	Pairs        []ContrastPair
+	Ratio        float64 // Lowest ratio across all evaluated pairs
+	PassesNormal bool    // Every pair meets 4.5:1
+	PassesLarge  bool    // Every pair meets 3:1
+}
+
+// ContrastReport evaluates text contrast of Color and Accent against Background
+// for both the normal-text (4.5:1) and large-text (3:1) WCAG thresholds.
+// Pairs whose colors cannot be parsed are reported with a zero ratio.
+func (dt *DesignTokens) ContrastReport() ContrastReport {
+	candidates := []struct {
+		name string
+		fg   string
+	}{
+		{"color/background", dt.Color},
+		{"accent/background", dt.Accent},
+	}
+
+	report := ContrastReport{PassesNormal: true, PassesLarge: true}
+	for i, candidate := range candidates {
+		pair := ContrastPair{
+			Name:       candidate.name,
+			Foreground: candidate.fg,
+			Background: dt.Background,
+		}
+		if ratio, err := ContrastRatio(candidate.fg, dt.Background); err == nil {
+			pair.Ratio = ratio
+			pair.PassesNormal = ratio >= contrastThreshold(WCAGNormalTextAA)
+			pair.PassesLarge = ratio >= contrastThreshold(WCAGLargeTextAA)
+		}
+
+		if i == 0 || pair.Ratio < report.Ratio {
+			report.Ratio = pair.Ratio
+		}
+		report.PassesNormal = report.PassesNormal && pair.PassesNormal
+		report.PassesLarge = report.PassesLarge && pair.PassesLarge
+		report.Pairs = append(report.Pairs, pair)
+	}
+
+	return report
+}
+
+// EnforceContrast returns a copy of the tokens with Color and Accent (and
+// their light/dark variants) adjusted in OKLCH lightness until they reach
+// minRatio against the matching background. minRatio is a WCAG 2.1 ratio;
+// under APCA the equivalent Lc is enforced. Colors that already pass, or
+// that cannot be parsed, are left unchanged.
+func (dt *DesignTokens) EnforceContrast(minRatio float64) *DesignTokens {
+	enforced := dt.clone()
+	pairs := []struct {
+		fg *string
+		bg string
+	}{
+		{&enforced.Color, enforced.Background},
+		{&enforced.Accent, enforced.Background},
+		{&enforced.ColorLight, enforced.BackgroundLight},
+		{&enforced.AccentLight, enforced.BackgroundLight},
+		{&enforced.ColorDark, enforced.BackgroundDark},
+		{&enforced.AccentDark, enforced.BackgroundDark},
+	}
+	for _, p := range pairs {
+		fg, err := parseTokenColor(*p.fg)
+		if err != nil {
+			continue
+		}
+		bg, err := parseTokenColor(p.bg)
+		if err != nil {
+			continue
+		}
+		if contrastRatio(fg, bg) < contrastThreshold(minRatio) {
+			*p.fg = colorToHex(adjustForContrast(fg, bg, minRatio))
+		}
+	}
+	return enforced
+}
+
+// adjustForContrast moves fg's perceptual lightness (preserving hue and
+// chroma) away from bg until the pair meets minRatio, a WCAG 2.1 ratio
+// converted for the active algorithm. If minRatio cannot be reached, the
+// closest achievable color is returned.
+func adjustForContrast(fg, bg color.Color, minRatio float64) color.Color {
+	minRatio = contrastThreshold(minRatio)
+	if contrastRatio(fg, bg) >= minRatio {
+		return fg
+	}
+
+	// Lighten on dark backgrounds, darken on light ones; pick whichever
+	// extreme (white or black) offers more headroom
+	white := color.RGB(1, 1, 1)
+	black := color.RGB(0, 0, 0)
+	lighten := contrastRatio(white, bg) >= contrastRatio(black, bg)
+
+	oklch := color.ToOKLCH(fg)
+	best := fg
+	for step := 1; step <= 100; step++ {
+		candidate := *oklch
+		if lighten {
+			candidate.L = oklch.L + (1-oklch.L)*float64(step)/100
+		} else {
+			candidate.L = oklch.L * (1 - float64(step)/100)
+		}
+		best = &candidate
+		if contrastRatio(best, bg) >= minRatio {
+			return best
+		}
+	}
+	return best
+}
@@ -0,0 +1,508 @@
+package design
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CSSOptions controls optional output of ToCSSWithOptions
+type CSSOptions struct {
+	// IncludeRGBChannels emits --color-rgb, --background-rgb and --accent-rgb
+	// as space-separated channels, e.g. rgb(var(--accent-rgb) / 0.5)
+	IncludeRGBChannels bool
+
+	// RelativeColors appends an @supports block overriding the derived
+	// accent states with live color-mix() expressions of var(--accent); the
+	// precomputed hex in :root remains for renderers without color-mix
+	RelativeColors bool
+
+	// PrefersContrastMore appends a @media (prefers-contrast: more) block
+	// overriding the variables that change when contrast is enforced at
+	// WCAGNormalTextAAA (7:1)
+	PrefersContrastMore bool
+
+	// FullRadiusPercent emits a full radius as --radius: 50% instead of the
+	// 9999px pill value, for square elements (avatars) meant to be circles
+	FullRadiusPercent bool
+
+	// RemRootPx, when > 0, emits the spacing scale (--space-*) in rem
+	// relative to this root font size instead of px
+	RemRootPx int
+}
+
+// Percentages mixed into var(--accent) for the relative hover/active states,
+// approximating one and two accentStateSteps of OKLCH lightness
+const (
+	relativeHoverMix  = 10
+	relativeActiveMix = 20
+)
+
+// cssVariableOrder is the canonical order of CSS custom properties. ToCSS
+// always emits variables in this order regardless of which optional fields are
+// set, so output stays byte-stable for diff-based caching. New variables must
+// be added here; variables missing from the list are emitted last, sorted by name.
+var cssVariableOrder = []string{
+	"--color",
+	"--color-rgb",
+	"--background",
+	"--background-rgb",
+	"--surface-0",
+	"--surface-1",
+	"--surface-2",
+	"--surface-3",
+	"--surface-4",
+	"--icon-color",
+	"--icon-color-1",
+	"--icon-color-2",
+	"--icon-color-3",
+	"--icon-color-4",
+	"--accent",
+	"--accent-rgb",
+	"--accent-on-surface",
+	"--accent-hover",
+	"--accent-active",
+	"--accent-foreground-disabled",
+	"--accent-gradient-start",
+	"--accent-gradient-end",
+	"--brand",
+	"--selection-bg",
+	"--selection-fg",
+	"--link",
+	"--link-visited",
+	"--scrollbar-track",
+	"--scrollbar-thumb",
+	"--scrollbar-thumb-hover",
+	"--font-family",
+	"--radius",
+	"--radius-sm",
+	"--radius-md",
+	"--radius-lg",
+	"--radius-xl",
+	"--padding",
+	"--space-xs",
+	"--space-s",
+	"--space-m",
+	"--space-l",
+	"--space-xl",
+	"--space-2xl",
+	"--line-height",
+	"--font-scale",
+	"--min-font-size",
+	"--backdrop-blur",
+	"--accent-overlay",
+	"--accent-blend",
+	"--text-shadow-color",
+	"--shadow-sm",
+	"--shadow-md",
+	"--shadow-lg",
+}
+
+// cssVariableRank returns the canonical position of a variable name
+func cssVariableRank(name string) int {
+	for i, n := range cssVariableOrder {
+		if n == name {
+			return i
+		}
+	}
+	return len(cssVariableOrder)
+}
+
+// cssVariable is a single CSS custom property emitted by ToCSS
+type cssVariable struct {
+	Name  string
+	Value string
+}
+
+// cssVariables returns the custom properties for the tokens in canonical order
+func (dt *DesignTokens) cssVariables(opts CSSOptions) []cssVariable {
+	vars := dt.collectCSSVariables(opts)
+	sort.SliceStable(vars, func(i, j int) bool {
+		ri, rj := cssVariableRank(vars[i].Name), cssVariableRank(vars[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return ri == len(cssVariableOrder) && vars[i].Name < vars[j].Name
+	})
+	return vars
+}
+
+// collectCSSVariables gathers the custom properties enabled for the tokens
+func (dt *DesignTokens) collectCSSVariables(opts CSSOptions) []cssVariable {
+	var vars []cssVariable
+
+	colors := []cssVariable{
+		{"--color", dt.Color},
+		{"--background", dt.Background},
+		{"--accent", dt.Accent},
+	}
+	for _, c := range colors {
+		vars = append(vars, c)
+		if opts.IncludeRGBChannels {
+			if channels, ok := rgbChannels(c.Value); ok {
+				vars = append(vars, cssVariable{c.Name + "-rgb", channels})
+			}
+		}
+	}
+
+	if _, err := parseTokenColor(dt.Background); err == nil {
+		for level := 0; level <= SurfaceLevels; level++ {
+			vars = append(vars, cssVariable{fmt.Sprintf("--surface-%d", level), dt.Surface(level)})
+		}
+		// Icon colors per surface: --icon-color on the base, --icon-color-N
+		// on --surface-N
+		vars = append(vars, cssVariable{"--icon-color", dt.IconColorForSurface(0)})
+		for level := 1; level <= SurfaceLevels; level++ {
+			vars = append(vars, cssVariable{fmt.Sprintf("--icon-color-%d", level), dt.IconColorForSurface(level)})
+		}
+	}
+
+	if _, err := parseTokenColor(dt.Accent); err == nil {
+		vars = append(vars,
+			cssVariable{"--accent-on-surface", dt.AccentOnSurface()},
+			cssVariable{"--accent-hover", dt.AccentHover()},
+			cssVariable{"--accent-active", dt.AccentActive()},
+			cssVariable{"--accent-foreground-disabled", dt.DisabledAccentText()},
+		)
+		start, end := dt.AccentGradientStops()
+		vars = append(vars,
+			cssVariable{"--accent-gradient-start", start},
+			cssVariable{"--accent-gradient-end", end},
+		)
+	}
+
+	if dt.Brand != "" {
+		vars = append(vars, cssVariable{"--brand", dt.Brand})
+	}
+
+	if selectionBg, selectionFg := dt.SelectionColors(); selectionBg != "" {
+		vars = append(vars,
+			cssVariable{"--selection-bg", selectionBg},
+			cssVariable{"--selection-fg", selectionFg},
+		)
+	}
+
+	if link, visited := dt.LinkColors(); link != "" {
+		vars = append(vars,
+			cssVariable{"--link", link},
+			cssVariable{"--link-visited", visited},
+		)
+	}
+
+	track, thumb, thumbHover := dt.ScrollbarColors()
+	vars = append(vars,
+		cssVariable{"--scrollbar-track", track},
+		cssVariable{"--scrollbar-thumb", thumb},
+		cssVariable{"--scrollbar-thumb-hover", thumbHover},
+	)
+
+	vars = append(vars,
+		cssVariable{"--font-family", dt.FontFamily},
+		cssVariable{"--radius", dt.cssRadiusWithOptions(opts)},
+		cssVariable{"--padding", fmt.Sprintf("%dpx", dt.Padding)},
+	)
+
+	if dt.Layout != nil {
+		vars = append(vars,
+			cssVariable{"--radius-sm", fmt.Sprintf("%dpx", dt.Layout.RadiusSM)},
+			cssVariable{"--radius-md", fmt.Sprintf("%dpx", dt.Layout.RadiusMD)},
+			cssVariable{"--radius-lg", fmt.Sprintf("%dpx", dt.Layout.RadiusLG)},
+			cssVariable{"--radius-xl", fmt.Sprintf("%dpx", dt.Layout.RadiusXL)},
+		)
+	}
+
+	if dt.Layout != nil {
+		for _, step := range dt.Layout.Scale() {
+			value := fmt.Sprintf("%dpx", step.Px)
+			if opts.RemRootPx > 0 {
+				value = pxToRem(step.Px, opts.RemRootPx)
+			}
+			vars = append(vars, cssVariable{"--space-" + step.Name, value})
+		}
+	}
+
+	if dt.Layout != nil && dt.Layout.LineHeight > 0 {
+		vars = append(vars,
+			cssVariable{"--line-height", strconv.FormatFloat(dt.Layout.LineHeight, 'f', -1, 64)},
+			cssVariable{"--font-scale", strconv.FormatFloat(dt.Layout.FontScale, 'f', -1, 64)},
+		)
+	}
+
+	vars = append(vars, cssVariable{"--min-font-size", fmt.Sprintf("%dpx", dt.MinReadableFontSize())})
+
+	if dt.BackdropBlur > 0 {
+		vars = append(vars, cssVariable{"--backdrop-blur", fmt.Sprintf("%dpx", dt.BackdropBlur)})
+	}
+
+	if shadow := dt.TextShadowColor(); shadow != "" {
+		vars = append(vars, cssVariable{"--text-shadow-color", shadow})
+	}
+
+	if dt.Flat {
+		vars = append(vars,
+			cssVariable{"--shadow-sm", "none"},
+			cssVariable{"--shadow-md", "none"},
+			cssVariable{"--shadow-lg", "none"},
+		)
+	}
+
+	if dt.AccentBlend != "" {
+		overlay, blend := dt.OverlayAccent(dt.AccentBlend)
+		vars = append(vars,
+			cssVariable{"--accent-overlay", overlay},
+			cssVariable{"--accent-blend", blend},
+		)
+	}
+
+	return vars
+}
+
+// relativeAccentState formats an accent interaction state as a color-mix()
+// of var(--accent) toward white or black, matching accentStateDirection
+func (dt *DesignTokens) relativeAccentState(percent int) string {
+	toward := "white"
+	if dt.accentStateDirection() < 0 {
+		toward = "black"
+	}
+	return fmt.Sprintf("color-mix(in oklab, var(--accent), %s %d%%)", toward, percent)
+}
+
+// cssRadius formats the radius. A full radius is emitted as the
+// RadiusFullPx sentinel (9999px), whatever pixel value is stored: CSS and SVG
+// clamp it to half the shorter side, so it is a pill on any box and a circle
+// on squares. 50% would instead give ellipses on non-square boxes, so it is
+// only used when CSSOptions.FullRadiusPercent asks for it.
+func (dt *DesignTokens) cssRadius() string {
+	return dt.cssRadiusWithOptions(CSSOptions{})
+}
+
+// cssRadiusWithOptions formats the radius, honoring opts.FullRadiusPercent
+func (dt *DesignTokens) cssRadiusWithOptions(opts CSSOptions) string {
+	if dt.IsRadiusFull() {
+		if opts.FullRadiusPercent {
+			return "50%"
+		}
+		return fmt.Sprintf("%dpx", RadiusFullPx)
+	}
+	return fmt.Sprintf("%dpx", dt.Radius)
+}
+
+// rgbChannels formats a color as space-separated 0-255 channels ("29 78 216"),
+// ignoring alpha so callers can supply their own
+func rgbChannels(value string) (string, bool) {
+	c, err := parseTokenColor(value)
+	if err != nil {
+		return "", false
+	}
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("%d %d %d", channelByte(r), channelByte(g), channelByte(b)), true
+}
+
+// ToCSS converts design tokens to CSS string for SVG.
+// Variables are emitted in the canonical order defined by cssVariableOrder.
+func (dt *DesignTokens) ToCSS() string {
+	return dt.ToCSSWithOptions(CSSOptions{})
+}
+
+// ToCSSWithOptions converts design tokens to CSS string for SVG,
+// including the optional variables enabled in opts
+func (dt *DesignTokens) ToCSSWithOptions(opts CSSOptions) string {
+	vars := dt.cssVariables(opts)
+	css := cssBlock(":root", vars)
+	if relative := dt.relativeColorVariables(opts); len(relative) > 0 {
+		css += cssConditionalBlock("@supports (color: color-mix(in oklab, red, red))", ":root", relative)
+	}
+	if changed := dt.reducedTransparencyVariables(vars, opts); len(changed) > 0 {
+		css += cssMediaBlock("(prefers-reduced-transparency: reduce)", ":root", changed)
+	}
+	if opts.PrefersContrastMore {
+		if changed := changedCSSVariables(vars, dt.EnforceContrast(WCAGNormalTextAAA).cssVariables(opts)); len(changed) > 0 {
+			css += cssMediaBlock("(prefers-contrast: more)", ":root", changed)
+		}
+	}
+	return css
+}
+
+// relativeColorVariables returns the color-mix() accent states enabled by
+// opts.RelativeColors, or nil when they are off or the accent is unparseable
+func (dt *DesignTokens) relativeColorVariables(opts CSSOptions) []cssVariable {
+	if !opts.RelativeColors {
+		return nil
+	}
+	if _, err := parseTokenColor(dt.Accent); err != nil {
+		return nil
+	}
+	return []cssVariable{
+		{"--accent-hover", dt.relativeAccentState(relativeHoverMix)},
+		{"--accent-active", dt.relativeAccentState(relativeActiveMix)},
+	}
+}
+
+// reducedTransparencyVariables returns the overrides ApplyReducedTransparency
+// implies for vars: opaque colors, and a zero blur in place of --backdrop-blur
+// (which the opaque tokens omit). Opaque tokens yield no overrides.
+func (dt *DesignTokens) reducedTransparencyVariables(vars []cssVariable, opts CSSOptions) []cssVariable {
+	changed := changedCSSVariables(vars, dt.ApplyReducedTransparency().cssVariables(opts))
+	if dt.BackdropBlur > 0 {
+		changed = append(changed, cssVariable{"--backdrop-blur", "0px"})
+		sort.SliceStable(changed, func(i, j int) bool {
+			return cssVariableRank(changed[i].Name) < cssVariableRank(changed[j].Name)
+		})
+	}
+	return changed
+}
+
+// changedCSSVariables returns the variables in next whose values differ from
+// the same-named variable in base
+func changedCSSVariables(base, next []cssVariable) []cssVariable {
+	values := make(map[string]string, len(base))
+	for _, v := range base {
+		values[v.Name] = v.Value
+	}
+	var changed []cssVariable
+	for _, v := range next {
+		if old, ok := values[v.Name]; !ok || old != v.Value {
+			changed = append(changed, v)
+		}
+	}
+	return changed
+}
+
+// CSSDiff returns a :root block with only the variables whose values differ
+// from base to target, for minimal client-side theme-switch payloads (the CSS
+// counterpart of DiffFrom). Variables base declares but target omits are
+// reset to initial so var() fallbacks apply again. Identical tokens yield "".
+func CSSDiff(base, target *DesignTokens) string {
+	baseVars := base.cssVariables(CSSOptions{})
+	targetVars := target.cssVariables(CSSOptions{})
+	changed := changedCSSVariables(baseVars, targetVars)
+
+	declared := make(map[string]bool, len(targetVars))
+	for _, v := range targetVars {
+		declared[v.Name] = true
+	}
+	for _, v := range baseVars {
+		if !declared[v.Name] {
+			changed = append(changed, cssVariable{v.Name, "initial"})
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+	sort.SliceStable(changed, func(i, j int) bool {
+		return cssVariableRank(changed[i].Name) < cssVariableRank(changed[j].Name)
+	})
+	return cssBlock(":root", changed)
+}
+
+// ToCSSScoped converts design tokens to a CSS block under a custom selector
+// (e.g. [data-theme="nord"][data-mode="dark"]) instead of :root, so several
+// themes can share one stylesheet
+func (dt *DesignTokens) ToCSSScoped(selector string) string {
+	return cssBlock(selector, dt.cssVariables(CSSOptions{}))
+}
+
+// ToCSSWithClasses emits class-based theme switching CSS from the tokens'
+// light and dark variants: ":root, .<lightClass> { ... }" followed by
+// ".<darkClass> { ... }". Tokens without variants render both blocks from the
+// base colors; for themes defined per mode, resolve with
+// ResolveDesignTokensForBothModes and use CSSWithClasses instead.
+func (dt *DesignTokens) ToCSSWithClasses(lightClass, darkClass string) string {
+	return CSSWithClasses(dt.LightMode(), dt.DarkMode(), lightClass, darkClass)
+}
+
+// CSSWithClasses emits class-based theme switching CSS for separately
+// resolved light and dark tokens (e.g. from ResolveDesignTokensForBothModes).
+// The light tokens also apply to :root. Class names may include the leading dot.
+func CSSWithClasses(light, dark *DesignTokens, lightClass, darkClass string) string {
+	lightSelector := ":root, ." + strings.TrimPrefix(lightClass, ".")
+	darkSelector := "." + strings.TrimPrefix(darkClass, ".")
+	return cssBlock(lightSelector, light.cssVariables(CSSOptions{})) +
+		cssBlock(darkSelector, dark.cssVariables(CSSOptions{}))
+}
+
+// ThemeSelector returns the attribute selector used for the tokens' theme and
+// mode in multi-theme stylesheets: [data-theme="nord"][data-mode="dark"]
+func (dt *DesignTokens) ThemeSelector() string {
+	return fmt.Sprintf(`[data-theme="%s"][data-mode="%s"]`, dt.Theme, dt.Mode)
+}
+
+// cssMediaBlock formats custom properties as a CSS rule for selector nested
+// in an @media query
+func cssMediaBlock(query, selector string, vars []cssVariable) string {
+	return cssConditionalBlock("@media "+query, selector, vars)
+}
+
+// cssConditionalBlock formats custom properties as a CSS rule for selector
+// nested in a conditional group rule such as @media or @supports
+func cssConditionalBlock(rule, selector string, vars []cssVariable) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n\t\t%s {\n\t\t\t%s {\n", rule, selector)
+	for _, v := range vars {
+		fmt.Fprintf(&b, "\t\t\t\t%s: %s;\n", v.Name, v.Value)
+	}
+	b.WriteString("\t\t\t}\n\t\t}\n\t")
+	return b.String()
+}
+
+// cssBlock formats custom properties as a CSS rule for selector
+func cssBlock(selector string, vars []cssVariable) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n\t\t%s {\n", selector)
+	for _, v := range vars {
+		fmt.Fprintf(&b, "\t\t\t%s: %s;\n", v.Name, v.Value)
+	}
+	b.WriteString("\t\t}\n\t")
+	return b.String()
+}
+
+// ToCSS converts layout tokens to a :root block of layout variables (spacing,
+// radius scale, --grid-columns, --grid-gap and typography metrics), followed
+// by an @media (min-width: Npx) block of grid overrides per breakpoint.
+// Breakpoints are emitted in ascending MinWidth order so wider viewports win
+// the cascade.
+func (lt *LayoutTokens) ToCSS() string {
+	var vars []cssVariable
+	for _, step := range lt.Scale() {
+		vars = append(vars, cssVariable{"--space-" + step.Name, fmt.Sprintf("%dpx", step.Px)})
+	}
+	vars = append(vars,
+		cssVariable{"--radius-sm", fmt.Sprintf("%dpx", lt.RadiusSM)},
+		cssVariable{"--radius-md", fmt.Sprintf("%dpx", lt.RadiusMD)},
+		cssVariable{"--radius-lg", fmt.Sprintf("%dpx", lt.RadiusLG)},
+		cssVariable{"--radius-xl", fmt.Sprintf("%dpx", lt.RadiusXL)},
+		cssVariable{"--grid-columns", strconv.Itoa(lt.DefaultGridColumns)},
+		cssVariable{"--grid-gap", cssPx(lt.DefaultGridGap)},
+	)
+	if lt.LineHeight > 0 {
+		vars = append(vars,
+			cssVariable{"--line-height", strconv.FormatFloat(lt.LineHeight, 'f', -1, 64)},
+			cssVariable{"--font-scale", strconv.FormatFloat(lt.FontScale, 'f', -1, 64)},
+		)
+	}
+	css := cssBlock(":root", vars)
+
+	breakpoints := append([]Breakpoint(nil), lt.Breakpoints...)
+	sort.SliceStable(breakpoints, func(i, j int) bool {
+		return breakpoints[i].MinWidth < breakpoints[j].MinWidth
+	})
+	for _, bp := range breakpoints {
+		var overrides []cssVariable
+		if bp.GridColumns > 0 {
+			overrides = append(overrides, cssVariable{"--grid-columns", strconv.Itoa(bp.GridColumns)})
+		}
+		if bp.GridGap > 0 {
+			overrides = append(overrides, cssVariable{"--grid-gap", cssPx(bp.GridGap)})
+		}
+		if len(overrides) > 0 {
+			css += cssMediaBlock(fmt.Sprintf("(min-width: %dpx)", bp.MinWidth), ":root", overrides)
+		}
+	}
+	return css
+}
+
+// cssPx formats a fractional pixel length without trailing zeros ("8px", "12.5px")
+func cssPx(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64) + "px"
+}
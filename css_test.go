@@ -0,0 +1,64 @@
+package design
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// TestToCSSGolden snapshots ToCSS for every built-in theme in each mode it
+// defines. Run "go test -run TestToCSSGolden -update" to refresh the files.
+func TestToCSSGolden(t *testing.T) {
+	for name, modes := range PreviewMatrix() {
+		for mode, tokens := range modes {
+			t.Run(name+"-"+mode, func(t *testing.T) {
+				path := filepath.Join("testdata", "css", name+"-"+mode+".golden")
+				got := tokens.ToCSS()
+
+				if *update {
+					if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+
+				want, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("reading golden file (run with -update to create it): %v", err)
+				}
+				if got != string(want) {
+					t.Errorf("ToCSS() mismatch for %s-%s\ngot:\n%s\nwant:\n%s", name, mode, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestReducedTransparencyBlock(t *testing.T) {
+	const query = "@media (prefers-reduced-transparency: reduce)"
+	tests := []struct {
+		params map[string]string
+		want   bool
+	}{
+		{map[string]string{"accent": "color(display-p3 1 0 0)"}, false},
+		{map[string]string{"accent": "hsl(200 50% 50%)"}, false},
+		{map[string]string{"background": "rgb(2 6 23)"}, false},
+		{map[string]string{"accent": "hsl(200 50% 50% / 0.5)"}, true},
+		{map[string]string{"glass": "0.6"}, true},
+	}
+	for _, tt := range tests {
+		tokens := ResolveDesignTokens(tt.params)
+		if got := strings.Contains(tokens.ToCSS(), query); got != tt.want {
+			t.Errorf("ToCSS(%v) has reduced-transparency block = %v, want %v", tt.params, got, tt.want)
+		}
+	}
+
+	tokens := ResolveDesignTokens(map[string]string{"accent": "color(display-p3 1 0 0)"})
+	if got := tokens.ApplyReducedTransparency().Accent; got != tokens.Accent {
+		t.Errorf("ApplyReducedTransparency() Accent = %q, want %q unchanged", got, tokens.Accent)
+	}
+}
@@ -0,0 +1,151 @@
+package design
+
+import (
+	"math"
+	"sync"
+)
+
+var (
+	defaultTokensMu sync.RWMutex
+	defaultTokens   *DesignTokens
+
+	maxScaledRadiusMu sync.RWMutex
+	maxScaledRadius   = DefaultMaxScaledRadius
+
+	contrastAlgorithmMu sync.RWMutex
+	contrastAlgorithm   = ContrastWCAG21
+
+	roundingModeMu sync.RWMutex
+	roundingMode   = RoundingRound
+)
+
+// Rounding modes for SetRoundingMode
+const (
+	RoundingFloor = "floor"
+	RoundingRound = "round" // default
+	RoundingCeil  = "ceil"
+)
+
+// DefaultMaxScaledRadius is the default cap on radii produced by Radix
+// scaling, just below the RadiusFullPx sentinel so a scaled radius can never
+// be mistaken for a full radius
+const DefaultMaxScaledRadius = RadiusFullPx - 1
+
+// SetDefaultTokens configures the baseline tokens ResolveDesignTokens starts
+// from when no theme is specified (e.g. a white-label brand theme).
+// The tokens are copied, so later changes to dt have no effect.
+// Passing nil restores the built-in default. Safe for concurrent use.
+func SetDefaultTokens(dt *DesignTokens) {
+	defaultTokensMu.Lock()
+	defer defaultTokensMu.Unlock()
+
+	if dt == nil {
+		defaultTokens = nil
+		return
+	}
+	defaultTokens = dt.clone()
+}
+
+// SetMaxScaledRadius caps the radius in px that the scaling param may produce
+// (e.g. 64 to keep scaled corners modest). Full radii are never scaled.
+// Values <= 0 restore DefaultMaxScaledRadius. Safe for concurrent use.
+func SetMaxScaledRadius(px int) {
+	maxScaledRadiusMu.Lock()
+	defer maxScaledRadiusMu.Unlock()
+
+	if px <= 0 || px > DefaultMaxScaledRadius {
+		px = DefaultMaxScaledRadius
+	}
+	maxScaledRadius = px
+}
+
+// maxScaledRadiusPx returns the cap set via SetMaxScaledRadius
+func maxScaledRadiusPx() int {
+	maxScaledRadiusMu.RLock()
+	defer maxScaledRadiusMu.RUnlock()
+	return maxScaledRadius
+}
+
+// SetContrastAlgorithm selects how contrast is measured: ContrastWCAG21
+// ("wcag21", the default) or ContrastAPCA ("apca"). It affects ContrastRatio,
+// MeetsWCAG, EnforceContrast and every automatic contrast adjustment; WCAG
+// thresholds are mapped to their APCA Lc equivalents. Unknown values restore
+// the default. Safe for concurrent use.
+func SetContrastAlgorithm(algo string) {
+	contrastAlgorithmMu.Lock()
+	defer contrastAlgorithmMu.Unlock()
+
+	if algo != ContrastAPCA {
+		algo = ContrastWCAG21
+	}
+	contrastAlgorithm = algo
+}
+
+// currentContrastAlgorithm returns the algorithm set via SetContrastAlgorithm
+func currentContrastAlgorithm() string {
+	contrastAlgorithmMu.RLock()
+	defer contrastAlgorithmMu.RUnlock()
+	return contrastAlgorithm
+}
+
+// SetRoundingMode selects how scaled dimensions (Radix scaling of padding and
+// radius, density-scaled spacing) are converted to whole pixels:
+// RoundingFloor, RoundingRound (the default) or RoundingCeil. Unknown values
+// restore the default. Safe for concurrent use.
+func SetRoundingMode(mode string) {
+	roundingModeMu.Lock()
+	defer roundingModeMu.Unlock()
+
+	if mode != RoundingFloor && mode != RoundingCeil {
+		mode = RoundingRound
+	}
+	roundingMode = mode
+}
+
+// roundScaled converts a scaled dimension to whole pixels using the mode set
+// via SetRoundingMode
+func roundScaled(v float64) int {
+	roundingModeMu.RLock()
+	mode := roundingMode
+	roundingModeMu.RUnlock()
+
+	switch mode {
+	case RoundingFloor:
+		return int(math.Floor(v))
+	case RoundingCeil:
+		return int(math.Ceil(v))
+	}
+	return int(math.Round(v))
+}
+
+// configuredDefaultTokens returns a copy of the tokens set via
+// SetDefaultTokens, or nil if none are configured
+func configuredDefaultTokens() *DesignTokens {
+	defaultTokensMu.RLock()
+	defer defaultTokensMu.RUnlock()
+
+	if defaultTokens == nil {
+		return nil
+	}
+	return defaultTokens.clone()
+}
+
+// clone returns a deep copy of the tokens, including layout tokens
+func (dt *DesignTokens) clone() *DesignTokens {
+	c := *dt
+	if dt.Layout != nil {
+		layout := *dt.Layout
+		layout.Breakpoints = append([]Breakpoint(nil), dt.Layout.Breakpoints...)
+		c.Layout = &layout
+	} else {
+		c.Layout = DefaultLayoutTokens()
+	}
+	return &c
+}
+
+// ThemeWasSpecified reports whether the tokens were resolved from an explicit
+// theme param. Theme reads "default" either way when none was given; this
+// tells an explicit theme=default apart from the configured fallback.
+func (dt *DesignTokens) ThemeWasSpecified() bool {
+	return dt.themeExplicit
+}
@@ -0,0 +1,44 @@
+package design
+
+import "testing"
+
+func TestSetRoundingMode(t *testing.T) {
+	defer SetRoundingMode(RoundingRound)
+
+	params := map[string]string{"scaling": "105%"}
+	tests := []struct {
+		mode string
+		want int // 16px scaled by 1.05 = 16.8px
+	}{
+		{"", 17}, // default
+		{RoundingRound, 17},
+		{RoundingFloor, 16},
+		{RoundingCeil, 17},
+		{"bogus", 17},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			if tt.mode != "" {
+				SetRoundingMode(tt.mode)
+			}
+			tokens := ResolveDesignTokens(params)
+			if tokens.Radius != tt.want {
+				t.Errorf("Radius = %d, want %d", tokens.Radius, tt.want)
+			}
+			if tokens.Padding != tt.want {
+				t.Errorf("Padding = %d, want %d", tokens.Padding, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundScaledTruncatesUnderFloor(t *testing.T) {
+	SetRoundingMode(RoundingFloor)
+	defer SetRoundingMode(RoundingRound)
+
+	for v, want := range map[float64]int{16.8: 16, 17.6: 17, 18: 18} {
+		if got := roundScaled(v); got != want {
+			t.Errorf("roundScaled(%g) = %d, want %d", v, got, want)
+		}
+	}
+}
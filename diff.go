@@ -0,0 +1,136 @@
+package design
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// DiffFrom returns the token values that differ from base, keyed like the
+// query parameters they resolve from (e.g. {"accent": "#FF0066"}). A field
+// cleared relative to base maps to "". Layout tokens that differ (e.g. from
+// grid_base, density or card_aspect) are included as JSON under "layout".
+func (dt *DesignTokens) DiffFrom(base *DesignTokens) map[string]string {
+	baseValues := map[string]string{}
+	for _, f := range base.tokenFields() {
+		baseValues[f.Key] = f.Value
+	}
+
+	diff := map[string]string{}
+	for _, f := range dt.tokenFields() {
+		if baseValues[f.Key] != f.Value {
+			diff[f.Key] = f.Value
+		}
+	}
+	if layout := layoutJSON(dt.Layout); layout != layoutJSON(base.Layout) {
+		diff["layout"] = layout
+	}
+	return diff
+}
+
+// layoutJSON encodes layout tokens for DiffFrom; nil encodes as "null"
+func layoutJSON(lt *LayoutTokens) string {
+	data, err := json.Marshal(lt)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ApplyDiff reconstructs tokens from a base and a diff produced by DiffFrom.
+// The base is not modified. Unknown keys or invalid numeric, boolean or
+// layout values return an error.
+func ApplyDiff(base *DesignTokens, diff map[string]string) (*DesignTokens, error) {
+	tokens := base.clone()
+	for key, value := range diff {
+		if err := tokens.setField(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return tokens, nil
+}
+
+// setField sets a token value by its tokenFields key
+func (dt *DesignTokens) setField(key, value string) error {
+	strField := map[string]*string{
+		"theme":              &dt.Theme,
+		"mode":               &dt.Mode,
+		"color":              &dt.Color,
+		"background":         &dt.Background,
+		"accent":             &dt.Accent,
+		"font_family":        &dt.FontFamily,
+		"density":            &dt.Density,
+		"color_light":        &dt.ColorLight,
+		"color_dark":         &dt.ColorDark,
+		"background_light":   &dt.BackgroundLight,
+		"background_dark":    &dt.BackgroundDark,
+		"accent_light":       &dt.AccentLight,
+		"accent_dark":        &dt.AccentDark,
+		"brand":              &dt.Brand,
+		"brand_light":        &dt.BrandLight,
+		"brand_dark":         &dt.BrandDark,
+		"selection_bg":       &dt.SelectionBackground,
+		"selection_fg":       &dt.SelectionColor,
+		"link":               &dt.Link,
+		"success":            &dt.Success,
+		"warning":            &dt.Warning,
+		"danger":             &dt.Danger,
+		"accent_blend":       &dt.AccentBlend,
+		"radix_accent_color": &dt.RadixAccentColor,
+		"radix_gray_color":   &dt.RadixGrayColor,
+		"radix_radius":       &dt.RadixRadius,
+		"radix_scaling":      &dt.RadixScaling,
+	}
+	if field, ok := strField[key]; ok {
+		*field = value
+		return nil
+	}
+
+	intField := map[string]*int{
+		"radius":        &dt.Radius,
+		"padding":       &dt.Padding,
+		"backdrop_blur": &dt.BackdropBlur,
+	}
+	if field, ok := intField[key]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		*field = n
+		return nil
+	}
+
+	boolField := map[string]*bool{
+		"radius_full":     &dt.RadiusFull,
+		"radius_explicit": &dt.RadiusExplicit,
+		"flat":            &dt.Flat,
+	}
+	if field, ok := boolField[key]; ok {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		*field = b
+		return nil
+	}
+
+	if key == "layout" {
+		layout := &LayoutTokens{}
+		if err := json.Unmarshal([]byte(value), layout); err != nil {
+			return fmt.Errorf("invalid layout %q: %w", value, err)
+		}
+		dt.Layout = layout
+		return nil
+	}
+
+	if key == "density_scale" {
+		scale, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		dt.DensityScale = scale
+		return nil
+	}
+
+	return fmt.Errorf("unknown token field %q", key)
+}
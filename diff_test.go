@@ -0,0 +1,47 @@
+package design
+
+import "testing"
+
+func TestApplyDiffRoundTrip(t *testing.T) {
+	base := ResolveDesignTokens(map[string]string{})
+
+	tests := []map[string]string{
+		{"theme": "nord", "mode": "light"},
+		{"accent_blend": "multiply", "flat": "true"},
+		{"density_scale": "0.75"},
+		{"density": "compact"},
+		{"grid_base": "5", "card_aspect": "1.5"},
+		{"radius": "full", "scaling": "110%"},
+		{"radius": "12", "scaling": "90%"},
+		{"accentColor": "blue", "grayColor": "slate"},
+		{"color": "111111/EEEEEE", "background": "FFFFFF/000000", "accent": "FF0066"},
+	}
+	for _, params := range tests {
+		want := ResolveDesignTokens(params)
+		got, err := ApplyDiff(base, want.DiffFrom(base))
+		if err != nil {
+			t.Fatalf("ApplyDiff(%v): %v", params, err)
+		}
+		if diff := got.DiffFrom(want); len(diff) != 0 {
+			t.Errorf("ApplyDiff(%v) differs from the original: %v", params, diff)
+		}
+		if got.ToCSS() != want.ToCSS() {
+			t.Errorf("ApplyDiff(%v) ToCSS mismatch\ngot:\n%s\nwant:\n%s", params, got.ToCSS(), want.ToCSS())
+		}
+	}
+}
+
+func TestApplyDiffInvalidValues(t *testing.T) {
+	base := ResolveDesignTokens(map[string]string{})
+	for _, diff := range []map[string]string{
+		{"unknown": "x"},
+		{"radius": "big"},
+		{"flat": "maybe"},
+		{"density_scale": "tight"},
+		{"layout": "{"},
+	} {
+		if _, err := ApplyDiff(base, diff); err == nil {
+			t.Errorf("ApplyDiff(%v) = nil error, want an error", diff)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package design
+
+// editorThemes are popular editor color schemes, as {color, background, accent}
+// per mode. Nord and Solarized are built in and not repeated here.
+var editorThemes = map[string]map[string][3]string{
+	"onedark": {
+		"dark":  {"#ABB2BF", "#282C34", "#61AFEF"},
+		"light": {"#383A42", "#FAFAFA", "#4078F2"}, // One Light
+	},
+	"dracula": {
+		"dark": {"#F8F8F2", "#282A36", "#BD93F9"},
+	},
+	"gruvbox": {
+		"dark":  {"#EBDBB2", "#282828", "#FE8019"},
+		"light": {"#3C3836", "#FBF1C7", "#AF3A03"},
+	},
+	"tokyonight": {
+		"dark":  {"#C0CAF5", "#1A1B26", "#7AA2F7"},
+		"light": {"#3760BF", "#E1E2E7", "#2E7DE9"}, // Tokyo Night Day
+	},
+	"catppuccinmocha": {
+		"dark": {"#CDD6F4", "#1E1E2E", "#CBA6F7"},
+	},
+}
+
+// RegisterEditorThemes registers the editor theme family in one call:
+// one-dark, dracula, gruvbox, tokyonight and catppuccin-mocha, alongside the
+// built-in nord and solarized. They behave like built-ins, including
+// "-light"/"-dark" suffixes; dracula and catppuccin-mocha are dark-only.
+func RegisterEditorThemes() {
+	for name, modes := range editorThemes {
+		tokens := DefaultTheme()
+		tokens.Theme = name
+		if dark, ok := modes["dark"]; ok {
+			tokens.ColorDark, tokens.BackgroundDark, tokens.AccentDark = dark[0], dark[1], dark[2]
+		}
+		if light, ok := modes["light"]; ok {
+			tokens.ColorLight, tokens.BackgroundLight, tokens.AccentLight = light[0], light[1], light[2]
+		}
+		RegisterTheme(name, tokens)
+	}
+
+	// "one-dark" splits into theme "one" + mode "dark", so alias the base name
+	RegisterThemeAlias("one", "onedark")
+	RegisterThemeAlias("catppuccin", "catppuccinmocha")
+}
@@ -0,0 +1,218 @@
+package design
+
+import (
+	"math"
+
+	"github.com/SCKelemen/color"
+)
+
+// Glass background limits
+const (
+	minGlassOpacity  = 0.1 // Below this, text over the glass becomes illegible
+	maxGlassOpacity  = 1.0
+	DefaultGlassBlur = 12 // Default backdrop blur in px for the glass param
+)
+
+// ApplyGlass turns the background into a translucent "glass" surface.
+// Opacity is clamped to [0.1, 1] and applied to Background and its light/dark
+// variants; blur is the backdrop blur radius in px emitted as --backdrop-blur.
+func (dt *DesignTokens) ApplyGlass(opacity float64, blur int) {
+	if opacity < minGlassOpacity {
+		opacity = minGlassOpacity
+	}
+	if opacity > maxGlassOpacity {
+		opacity = maxGlassOpacity
+	}
+	if blur < 0 {
+		blur = 0
+	}
+
+	dt.Background = withAlpha(dt.Background, opacity)
+	if dt.BackgroundLight != "" {
+		dt.BackgroundLight = withAlpha(dt.BackgroundLight, opacity)
+	}
+	if dt.BackgroundDark != "" {
+		dt.BackgroundDark = withAlpha(dt.BackgroundDark, opacity)
+	}
+	dt.BackdropBlur = blur
+}
+
+// TextShadowColor returns a text-shadow color suited to the theme surface:
+// a translucent darkened background in light mode, a subtle translucent black
+// on mid-dark backgrounds, and "" when the background is already so dark that
+// a shadow would be invisible. Flat tokens have no text shadow.
+func (dt *DesignTokens) TextShadowColor() string {
+	if dt.Flat {
+		return ""
+	}
+	bg, err := parseTokenColor(dt.Background)
+	if err != nil {
+		return ""
+	}
+
+	if dt.Mode == "light" {
+		return colorToHex(color.Darken(bg.WithAlpha(1), 0.45).WithAlpha(0.35))
+	}
+	if relativeLuminance(bg) < 0.02 {
+		return ""
+	}
+	return "#00000066"
+}
+
+// Flatten returns a copy of the tokens for a strictly flat design: elevation
+// shadows are emitted as none, accent gradients collapse to the solid accent,
+// and the backdrop blur and text shadow are dropped. Colors (including glass
+// transparency) and radius are unchanged.
+func (dt *DesignTokens) Flatten() *DesignTokens {
+	flat := dt.clone()
+	flat.Flat = true
+	flat.BackdropBlur = 0
+	return flat
+}
+
+// oledColorLift is the OKLCH lightness added to text on true-black backgrounds
+const oledColorLift = 0.04
+
+// ApplyOLED returns a copy of the tokens with a true-black (#000000)
+// background for OLED displays and slightly brighter text to compensate for
+// the harsher contrast. Only dark mode is affected; in light mode the copy is
+// unchanged.
+func (dt *DesignTokens) ApplyOLED() *DesignTokens {
+	oled := dt.clone()
+	if dt.Mode != "dark" {
+		return oled
+	}
+
+	oled.Background = "#000000"
+	oled.BackgroundDark = "#000000"
+	for _, field := range []*string{&oled.Color, &oled.ColorDark} {
+		if c, err := parseTokenColor(*field); err == nil {
+			*field = colorToHex(color.Lighten(c, oledColorLift))
+		}
+	}
+	return oled
+}
+
+// ApplyReducedTransparency returns a copy of the tokens for users who prefer
+// reduced transparency: every translucent color (including glass backgrounds
+// and brand colors) is made fully opaque and the backdrop blur is dropped.
+// Opaque colors are kept byte-for-byte.
+func (dt *DesignTokens) ApplyReducedTransparency() *DesignTokens {
+	opaque := dt.clone()
+	fields := append(opaque.colorFields(), &opaque.Brand, &opaque.BrandLight, &opaque.BrandDark)
+	for _, field := range fields {
+		if c, err := parseTokenColor(*field); err == nil && c.Alpha() < 1 {
+			*field = colorToHex(c.WithAlpha(1))
+		}
+	}
+	opaque.BackdropBlur = 0
+	return opaque
+}
+
+// Elevation surfaces: OKLAB mix toward white (dark mode) or black (light
+// mode) per level, and the number of levels emitted by ToCSS (--surface-0 …)
+const (
+	surfaceLiftDark   = 0.05
+	surfaceShadeLight = 0.025
+	SurfaceLevels     = 4
+)
+
+// Surface returns the surface color at an elevation level: the Background at
+// level 0 (or below), then progressively lighter surfaces in dark mode or
+// subtly shaded ones in light mode. Nested cards use one level per depth.
+func (dt *DesignTokens) Surface(level int) string {
+	if level <= 0 {
+		return dt.Background
+	}
+	if dt.Mode == "light" {
+		return mixColors(dt.Background, "#000000", math.Min(1, surfaceShadeLight*float64(level)))
+	}
+	return mixColors(dt.Background, "#FFFFFF", math.Min(1, surfaceLiftDark*float64(level)))
+}
+
+// Default accent tint strengths per mode. Dark surfaces carry more tint than
+// light ones before starting to look garish.
+const (
+	DefaultTintLight = 0.04
+	DefaultTintDark  = 0.08
+)
+
+// hasTintParams reports whether any tint param was given
+func hasTintParams(queryParams map[string]string) bool {
+	if queryParams["tint"] == "true" {
+		return true
+	}
+	_, light := queryParams["tint_light"]
+	_, dark := queryParams["tint_dark"]
+	return light || dark
+}
+
+// TintBackgroundForMode mixes the accent into the background (in OKLAB) by
+// light in light mode and dark in dark mode; both are clamped to [0, 1].
+// BackgroundLight and BackgroundDark are tinted with their mode's strength
+// and accent variant, so adaptive output stays consistent.
+func (dt *DesignTokens) TintBackgroundForMode(light, dark float64) {
+	light = math.Max(0, math.Min(1, light))
+	dark = math.Max(0, math.Min(1, dark))
+
+	pick := func(variant string) string {
+		if variant != "" {
+			return variant
+		}
+		return dt.Accent
+	}
+
+	if dt.BackgroundLight != "" {
+		dt.BackgroundLight = mixColors(dt.BackgroundLight, pick(dt.AccentLight), light)
+	}
+	if dt.BackgroundDark != "" {
+		dt.BackgroundDark = mixColors(dt.BackgroundDark, pick(dt.AccentDark), dark)
+	}
+	if dt.Mode == "light" {
+		dt.Background = mixColors(dt.Background, dt.Accent, light)
+	} else {
+		dt.Background = mixColors(dt.Background, dt.Accent, dark)
+	}
+}
+
+// IconColorForSurface returns an icon color legible on the surface at the
+// given elevation level (see Surface): the text Color, adjusted in lightness
+// as needed to reach the 3:1 WCAG minimum for non-text graphics
+func (dt *DesignTokens) IconColorForSurface(level int) string {
+	fg, err := parseTokenColor(dt.Color)
+	if err != nil {
+		return dt.Color
+	}
+	surface, err := parseTokenColor(dt.Surface(level))
+	if err != nil {
+		return dt.Color
+	}
+	return colorToHex(adjustForContrast(fg, surface, WCAGLargeTextAA))
+}
+
+// Scrollbar mixes of the text Color into the Background (OKLAB weights), and
+// the minimum thumb/track contrast that keeps the thumb findable while staying
+// well below text contrast
+const (
+	scrollbarTrackMix      = 0.04
+	scrollbarThumbMix      = 0.22
+	scrollbarThumbHoverMix = 0.36
+	scrollbarThumbContrast = 1.5
+)
+
+// ScrollbarColors returns scrollbar colors matching the theme: a track barely
+// off the Background, a thumb mixed further toward the text Color (raised to
+// at least 1.5:1 against the track if needed, so it stays visible without
+// drawing the eye), and a more pronounced hover thumb
+func (dt *DesignTokens) ScrollbarColors() (track, thumb, thumbHover string) {
+	track = mixColors(dt.Background, dt.Color, scrollbarTrackMix)
+	thumb = mixColors(dt.Background, dt.Color, scrollbarThumbMix)
+	thumbHover = mixColors(dt.Background, dt.Color, scrollbarThumbHoverMix)
+
+	t, errT := parseTokenColor(track)
+	th, errTh := parseTokenColor(thumb)
+	if errT == nil && errTh == nil && contrastRatio(th, t) < contrastThreshold(scrollbarThumbContrast) {
+		thumb = colorToHex(adjustForContrast(th, t, scrollbarThumbContrast))
+	}
+	return track, thumb, thumbHover
+}
@@ -0,0 +1,330 @@
+package design
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokenField is a named resolved token value used by the export formats.
+// Key matches the query parameter name the value resolves from.
+type tokenField struct {
+	Key   string
+	Value string
+}
+
+// tokenFields returns every exportable token value (including empty ones)
+// in a stable order
+func (dt *DesignTokens) tokenFields() []tokenField {
+	return []tokenField{
+		{"theme", dt.Theme},
+		{"mode", dt.Mode},
+		{"color", dt.Color},
+		{"background", dt.Background},
+		{"accent", dt.Accent},
+		{"font_family", dt.FontFamily},
+		{"radius", strconv.Itoa(dt.Radius)},
+		{"radius_full", strconv.FormatBool(dt.RadiusFull)},
+		{"radius_explicit", strconv.FormatBool(dt.RadiusExplicit)},
+		{"padding", strconv.Itoa(dt.Padding)},
+		{"density", dt.Density},
+		{"density_scale", strconv.FormatFloat(dt.DensityScale, 'g', -1, 64)},
+		{"color_light", dt.ColorLight},
+		{"color_dark", dt.ColorDark},
+		{"background_light", dt.BackgroundLight},
+		{"background_dark", dt.BackgroundDark},
+		{"accent_light", dt.AccentLight},
+		{"accent_dark", dt.AccentDark},
+		{"brand", dt.Brand},
+		{"brand_light", dt.BrandLight},
+		{"brand_dark", dt.BrandDark},
+		{"selection_bg", dt.SelectionBackground},
+		{"selection_fg", dt.SelectionColor},
+		{"link", dt.Link},
+		{"success", dt.Success},
+		{"warning", dt.Warning},
+		{"danger", dt.Danger},
+		{"accent_blend", dt.AccentBlend},
+		{"backdrop_blur", strconv.Itoa(dt.BackdropBlur)},
+		{"flat", strconv.FormatBool(dt.Flat)},
+		{"radix_accent_color", dt.RadixAccentColor},
+		{"radix_gray_color", dt.RadixGrayColor},
+		{"radix_radius", dt.RadixRadius},
+		{"radix_scaling", dt.RadixScaling},
+	}
+}
+
+// resolvedFields returns the non-empty resolved token values in a stable order
+func (dt *DesignTokens) resolvedFields() []tokenField {
+	fields := dt.tokenFields()
+	resolved := fields[:0]
+	for _, f := range fields {
+		if f.Value != "" {
+			resolved = append(resolved, f)
+		}
+	}
+	return resolved
+}
+
+// ToDotEnv exports the resolved tokens as dotenv lines (PREFIX_COLOR="#E5E7EB").
+// Values containing '#', whitespace or quotes are double-quoted so shells
+// don't treat them as comments. An empty prefix emits bare keys.
+func (dt *DesignTokens) ToDotEnv(prefix string) string {
+	prefix = strings.ToUpper(strings.TrimSuffix(prefix, "_"))
+
+	var b strings.Builder
+	for _, f := range dt.resolvedFields() {
+		key := strings.ToUpper(f.Key)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		fmt.Fprintf(&b, "%s=%s\n", key, dotEnvValue(f.Value))
+	}
+	return b.String()
+}
+
+// dotEnvValue quotes a dotenv value when it would otherwise be misparsed
+func dotEnvValue(value string) string {
+	if !strings.ContainsAny(value, "# \t\"'\\$") {
+		return value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(value)
+	return `"` + escaped + `"`
+}
+
+// ToJSStyleObject exports the CSS custom properties as a JSON object
+// ({"--color": "#E5E7EB", "--radius": "16px", ...}) suitable for spreading
+// into a React style prop. Keys and order match ToCSS.
+func (dt *DesignTokens) ToJSStyleObject() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, v := range dt.cssVariables(CSSOptions{}) {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(v.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// tailwindConfig is the theme.extend fragment emitted by ToTailwindConfig
+type tailwindConfig struct {
+	Colors       map[string]string `json:"colors"`
+	BorderRadius map[string]string `json:"borderRadius"`
+	Spacing      map[string]string `json:"spacing,omitempty"`
+}
+
+// ToTailwindConfig exports the tokens as a JSON fragment mergeable into
+// theme.extend of tailwind.config.js: colors (foreground, surface, accent and
+// brand when set), the card border radius and the spacing scale (xs…2xl).
+func (dt *DesignTokens) ToTailwindConfig() ([]byte, error) {
+	cfg := tailwindConfig{
+		Colors: map[string]string{
+			"foreground": dt.Color,
+			"surface":    dt.Background,
+			"accent":     dt.Accent,
+		},
+		BorderRadius: map[string]string{
+			"card": dt.cssRadius(),
+		},
+	}
+	if dt.Brand != "" {
+		cfg.Colors["brand"] = dt.Brand
+	}
+	if dt.Layout != nil {
+		cfg.Spacing = map[string]string{}
+		for _, step := range dt.Layout.Scale() {
+			cfg.Spacing[step.Name] = fmt.Sprintf("%dpx", step.Px)
+		}
+	}
+	return json.Marshal(cfg)
+}
+
+// Swatch is a named sRGB color in the ToSwatchJSON format
+type Swatch struct {
+	Name string `json:"name"`
+	Hex  string `json:"hex"` // #RRGGBB, alpha dropped
+	RGB  [3]int `json:"rgb"` // 0-255 channels
+}
+
+// SwatchList is the document emitted by ToSwatchJSON
+type SwatchList struct {
+	Name     string   `json:"name"` // "<theme>-<mode>"
+	Swatches []Swatch `json:"swatches"`
+}
+
+// ToSwatchJSON exports every resolved color as a JSON swatch list for import
+// into design tools: the base colors, their light/dark variants, the brand
+// colors and the derived accent states (accent_on_surface, accent_hover,
+// accent_active). Names match the query parameter keys; empty and
+// unparseable colors are skipped.
+func (dt *DesignTokens) ToSwatchJSON() ([]byte, error) {
+	list := SwatchList{Name: dt.Theme + "-" + dt.Mode, Swatches: []Swatch{}}
+	for _, f := range dt.exportColorFields() {
+		c, _ := parseTokenColor(f.Value)
+		r, g, b, _ := c.RGBA()
+		rgb := [3]int{int(channelByte(r)), int(channelByte(g)), int(channelByte(b))}
+		list.Swatches = append(list.Swatches, Swatch{
+			Name: f.Key,
+			Hex:  fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2]),
+			RGB:  rgb,
+		})
+	}
+	return json.Marshal(list)
+}
+
+// exportColorFields returns every parseable resolved color for the native
+// and design-tool exports: base colors, derived accent states, light/dark
+// variants and brand colors, keyed like the query parameters
+func (dt *DesignTokens) exportColorFields() []tokenField {
+	fields := []tokenField{
+		{"color", dt.Color},
+		{"background", dt.Background},
+		{"accent", dt.Accent},
+	}
+	if _, err := parseTokenColor(dt.Accent); err == nil {
+		fields = append(fields,
+			tokenField{"accent_on_surface", dt.AccentOnSurface()},
+			tokenField{"accent_hover", dt.AccentHover()},
+			tokenField{"accent_active", dt.AccentActive()},
+		)
+	}
+	fields = append(fields,
+		tokenField{"color_light", dt.ColorLight},
+		tokenField{"color_dark", dt.ColorDark},
+		tokenField{"background_light", dt.BackgroundLight},
+		tokenField{"background_dark", dt.BackgroundDark},
+		tokenField{"accent_light", dt.AccentLight},
+		tokenField{"accent_dark", dt.AccentDark},
+		tokenField{"brand", dt.Brand},
+		tokenField{"brand_light", dt.BrandLight},
+		tokenField{"brand_dark", dt.BrandDark},
+	)
+
+	parseable := fields[:0]
+	for _, f := range fields {
+		if _, err := parseTokenColor(f.Value); err == nil {
+			parseable = append(parseable, f)
+		}
+	}
+	return parseable
+}
+
+// exportIdentifier converts a token key to a lowerCamelCase identifier
+// (accent_on_surface → accentOnSurface)
+func exportIdentifier(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// ToSwiftColors exports every resolved color as SwiftUI constants, one per
+// line: static let background = Color(hex: "#020617"). Translucent colors
+// use #RRGGBBAA.
+func (dt *DesignTokens) ToSwiftColors() string {
+	var b strings.Builder
+	for _, f := range dt.exportColorFields() {
+		c, _ := parseTokenColor(f.Value)
+		fmt.Fprintf(&b, "static let %s = Color(hex: %q)\n", exportIdentifier(f.Key), colorToHex(c))
+	}
+	return b.String()
+}
+
+// ToKotlinColors exports every resolved color as Jetpack Compose constants,
+// one per line, with ARGB literals: val background = Color(0xFF020617)
+func (dt *DesignTokens) ToKotlinColors() string {
+	var b strings.Builder
+	for _, f := range dt.exportColorFields() {
+		c, _ := parseTokenColor(f.Value)
+		r, g, bl, a := c.RGBA()
+		fmt.Fprintf(&b, "val %s = Color(0x%02X%02X%02X%02X)\n", exportIdentifier(f.Key),
+			channelByte(a), channelByte(r), channelByte(g), channelByte(bl))
+	}
+	return b.String()
+}
+
+// ToGPL exports every resolved color as a GIMP/Inkscape palette (.gpl) named
+// name (the theme name if empty), one "R G B<tab>Name" line per color
+func (dt *DesignTokens) ToGPL(name string) string {
+	if name == "" {
+		name = dt.Theme
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "GIMP Palette\nName: %s\nColumns: 0\n#\n", name)
+	for _, f := range dt.exportColorFields() {
+		c, _ := parseTokenColor(f.Value)
+		r, g, bl, _ := c.RGBA()
+		fmt.Fprintf(&b, "%3d %3d %3d\t%s\n", channelByte(r), channelByte(g), channelByte(bl), f.Key)
+	}
+	return b.String()
+}
+
+// Describe returns a one-line summary for logs and debug UIs, e.g.
+// "nord (dark): bg #2E3440, fg #ECEFF4, accent #5E81AC, radius 16px,
+// comfortable", followed by the active extras: light/dark variants, the
+// Radix accent/gray, glass and flat.
+func (dt *DesignTokens) Describe() string {
+	radius := fmt.Sprintf("%dpx", dt.Radius)
+	if dt.IsRadiusFull() {
+		radius = "full"
+	}
+	parts := []string{
+		"bg " + dt.Background,
+		"fg " + dt.Color,
+		"accent " + dt.Accent,
+		"radius " + radius,
+		dt.Density,
+	}
+
+	if dt.ColorLight != "" || dt.ColorDark != "" || dt.BackgroundLight != "" ||
+		dt.BackgroundDark != "" || dt.AccentLight != "" || dt.AccentDark != "" {
+		parts = append(parts, "variants")
+	}
+	if dt.RadixAccentColor != "" || dt.RadixGrayColor != "" {
+		parts = append(parts, "radix "+strings.Trim(dt.RadixAccentColor+"/"+dt.RadixGrayColor, "/"))
+	}
+	if dt.BackdropBlur > 0 {
+		parts = append(parts, "glass")
+	}
+	if dt.Flat {
+		parts = append(parts, "flat")
+	}
+
+	return fmt.Sprintf("%s (%s): %s", dt.Theme, dt.Mode, strings.Join(parts, ", "))
+}
+
+// visualHashLength is the number of hex digits VisualHash keeps
+const visualHashLength = 16
+
+// VisualHash returns a short fingerprint of how the tokens render: a SHA-256
+// over the canonical ToCSS variables (every resolved color plus radius,
+// padding, spacing and typography), independent of the theme name. Tokens
+// that would render identically share a hash, so CI can detect unintended
+// visual changes to themes.
+func (dt *DesignTokens) VisualHash() string {
+	h := sha256.New()
+	for _, v := range dt.cssVariables(CSSOptions{}) {
+		fmt.Fprintf(h, "%s:%s;", v.Name, v.Value)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:visualHashLength]
+}
@@ -0,0 +1,51 @@
+package design
+
+import (
+	"math"
+
+	"github.com/SCKelemen/color"
+)
+
+// Supported gamuts for ClampToGamut
+const (
+	GamutSRGB        = "srgb"         // Clamp to the sRGB cube
+	GamutGrayscale16 = "grayscale-16" // 16 evenly spaced grays (e-ink)
+	GamutWebSafe     = "web-safe"     // 216-color web-safe palette
+)
+
+// ClampToGamut returns a copy of the tokens with every color snapped to the
+// nearest in-gamut value for "srgb", "grayscale-16" or "web-safe". Alpha and
+// non-color fields are left untouched; unknown gamuts return an unchanged copy.
+func (dt *DesignTokens) ClampToGamut(gamut string) *DesignTokens {
+	clamped := dt.clone()
+
+	var snap func(r, g, b float64) (float64, float64, float64)
+	switch gamut {
+	case GamutSRGB:
+		// Channels are already clamped to [0, 1] when converting to RGBA
+		snap = func(r, g, b float64) (float64, float64, float64) { return r, g, b }
+	case GamutGrayscale16:
+		snap = func(r, g, b float64) (float64, float64, float64) {
+			gray := quantize(0.2126*r+0.7152*g+0.0722*b, 15)
+			return gray, gray, gray
+		}
+	case GamutWebSafe:
+		snap = func(r, g, b float64) (float64, float64, float64) {
+			return quantize(r, 5), quantize(g, 5), quantize(b, 5)
+		}
+	default:
+		return clamped
+	}
+
+	clamped.mapColors(func(c color.Color) color.Color {
+		r, g, b, a := c.RGBA()
+		r, g, b = snap(r, g, b)
+		return color.NewRGBA(r, g, b, a)
+	})
+	return clamped
+}
+
+// quantize snaps a [0, 1] value to the nearest of steps+1 evenly spaced levels
+func quantize(v float64, steps int) float64 {
+	return math.Round(v*float64(steps)) / float64(steps)
+}
@@ -0,0 +1,169 @@
+package design
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/SCKelemen/color"
+)
+
+// Perceptual (OKLCH) targets for generated theme surfaces
+const (
+	generatedDarkBackgroundL  = 0.18
+	generatedDarkColorL       = 0.93
+	generatedLightBackgroundL = 0.98
+	generatedLightColorL      = 0.25
+	generatedBackgroundChroma = 0.03 // Maximum hue tint carried into backgrounds
+	generatedColorChroma      = 0.01 // Text stays nearly neutral
+)
+
+// GenerateTheme derives a complete theme from a single seed color. The seed
+// becomes the accent (adjusted only as needed for AA contrast) and tints the
+// background and text. The result is in dark mode with light variants set,
+// so LightMode() and DarkMode() both work.
+func GenerateTheme(seed string) (*DesignTokens, error) {
+	c, err := parseTokenColor(seed)
+	if err != nil {
+		return nil, err
+	}
+	return generateTheme(c, "generated"), nil
+}
+
+// GenerateThemePack derives a coordinated set of themes from one seed color
+// using hue harmonies: "primary" (the seed), "complementary" (+180°),
+// "analogous-1"/"analogous-2" (∓30°) and "triadic-1"/"triadic-2" (+120°/+240°).
+// Every theme independently passes AA contrast. Returns nil if the seed
+// cannot be parsed.
+func GenerateThemePack(seed string) map[string]*DesignTokens {
+	c, err := parseTokenColor(seed)
+	if err != nil {
+		return nil
+	}
+
+	harmonies := []struct {
+		name  string
+		shift float64
+	}{
+		{"primary", 0},
+		{"complementary", 180},
+		{"analogous-1", -30},
+		{"analogous-2", 30},
+		{"triadic-1", 120},
+		{"triadic-2", 240},
+	}
+
+	pack := make(map[string]*DesignTokens, len(harmonies))
+	for _, h := range harmonies {
+		pack[h.name] = generateTheme(rotateHue(c, h.shift), "generated-"+h.name)
+	}
+	return pack
+}
+
+// generateTheme builds light and dark palettes around an accent color
+func generateTheme(accent color.Color, name string) *DesignTokens {
+	seed := color.ToOKLCH(accent)
+	bgChroma := math.Min(seed.C, generatedBackgroundChroma)
+
+	darkBg := color.NewOKLCH(generatedDarkBackgroundL, bgChroma, seed.H, 1)
+	darkFg := color.NewOKLCH(generatedDarkColorL, generatedColorChroma, seed.H, 1)
+	lightBg := color.NewOKLCH(generatedLightBackgroundL, bgChroma/2, seed.H, 1)
+	lightFg := color.NewOKLCH(generatedLightColorL, generatedColorChroma, seed.H, 1)
+
+	tokens := DefaultTheme()
+	tokens.Theme = name
+	tokens.Mode = "dark"
+
+	tokens.ColorDark = colorToHex(adjustForContrast(darkFg, darkBg, WCAGNormalTextAA))
+	tokens.BackgroundDark = colorToHex(darkBg)
+	tokens.AccentDark = colorToHex(adjustForContrast(accent, darkBg, WCAGNormalTextAA))
+	tokens.ColorLight = colorToHex(adjustForContrast(lightFg, lightBg, WCAGNormalTextAA))
+	tokens.BackgroundLight = colorToHex(lightBg)
+	tokens.AccentLight = colorToHex(adjustForContrast(accent, lightBg, WCAGNormalTextAA))
+
+	tokens.Color = tokens.ColorDark
+	tokens.Background = tokens.BackgroundDark
+	tokens.Accent = tokens.AccentDark
+
+	return tokens
+}
+
+// rotateHue shifts a color's OKLCH hue by degrees, preserving lightness and chroma
+func rotateHue(c color.Color, degrees float64) color.Color {
+	oklch := color.ToOKLCH(c)
+	oklch.H = math.Mod(oklch.H+degrees+360, 360)
+	return oklch
+}
+
+// ThemeFromPalette maps a set of extracted colors (e.g. from an image; the
+// caller does the extraction) to theme tokens for mode ("light" or "dark").
+// The darkest color (lightest in light mode) becomes the background, the one
+// contrasting most with it the text color, and the most saturated of the rest
+// the accent; text and accent are then adjusted as needed to pass AA (4.5:1).
+// Ties keep the earlier color, so the result is deterministic for a given
+// input order. Missing roles fall back: a single color gets white or black
+// text, and a two-color palette reuses the text color as the accent.
+func ThemeFromPalette(colors []string, mode string) (*DesignTokens, error) {
+	if mode != "light" && mode != "dark" {
+		return nil, fmt.Errorf("invalid mode %q", mode)
+	}
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("empty palette")
+	}
+
+	parsed := make([]color.Color, len(colors))
+	for i, value := range colors {
+		c, err := parseTokenColor(value)
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = c
+	}
+
+	// Background: luminance extreme for the mode
+	bgIdx := 0
+	for i, c := range parsed {
+		l, best := relativeLuminance(c), relativeLuminance(parsed[bgIdx])
+		if (mode == "dark" && l < best) || (mode == "light" && l > best) {
+			bgIdx = i
+		}
+	}
+	bg := parsed[bgIdx]
+
+	// Text: strongest contrast with the background
+	fgIdx := -1
+	for i, c := range parsed {
+		if i != bgIdx && (fgIdx < 0 || contrastRatio(c, bg) > contrastRatio(parsed[fgIdx], bg)) {
+			fgIdx = i
+		}
+	}
+
+	// Accent: most saturated of the remaining colors
+	accentIdx := -1
+	for i, c := range parsed {
+		if i != bgIdx && i != fgIdx && (accentIdx < 0 || color.ToOKLCH(c).C > color.ToOKLCH(parsed[accentIdx]).C) {
+			accentIdx = i
+		}
+	}
+
+	fg, _ := parseTokenColor(bestForeground(colorToHex(bg)))
+	if fgIdx >= 0 {
+		fg = parsed[fgIdx]
+	}
+	accent := fg
+	if accentIdx >= 0 {
+		accent = parsed[accentIdx]
+	}
+
+	tokens := DefaultTheme()
+	tokens.Theme = "palette"
+	tokens.Mode = mode
+	tokens.Background = colorToHex(bg)
+	tokens.Color = colorToHex(adjustForContrast(fg, bg, WCAGNormalTextAA))
+	tokens.Accent = colorToHex(adjustForContrast(accent, bg, WCAGNormalTextAA))
+	if mode == "light" {
+		tokens.ColorLight, tokens.BackgroundLight, tokens.AccentLight = tokens.Color, tokens.Background, tokens.Accent
+	} else {
+		tokens.ColorDark, tokens.BackgroundDark, tokens.AccentDark = tokens.Color, tokens.Background, tokens.Accent
+	}
+	return tokens, nil
+}
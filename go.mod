@@ -2,4 +2,4 @@ module github.com/SCKelemen/design-system
 
 go 1.25.4
 
-require github.com/SCKelemen/color v1.0.0 // indirect
+require github.com/SCKelemen/color v1.0.0
@@ -0,0 +1,187 @@
+package design
+
+import (
+	"math"
+	"strconv"
+)
+
+// DefaultGridBase is the base unit in px of the default spacing scale
+const DefaultGridBase = 4
+
+// LayoutTokensFromBase returns the default layout tokens with the spacing
+// scale re-derived as multiples of base (xs=1×, s=2×, m=4×, l=5×, xl=6×,
+// 2xl=8×). Card paddings and grid gap follow the same multiples they have on
+// the default 4px grid. Non-positive bases fall back to DefaultGridBase.
+func LayoutTokensFromBase(base int) *LayoutTokens {
+	if base <= 0 {
+		base = DefaultGridBase
+	}
+
+	lt := DefaultLayoutTokens()
+
+	lt.SpaceXS = base
+	lt.SpaceS = 2 * base
+	lt.SpaceM = 4 * base
+	lt.SpaceL = 5 * base
+	lt.SpaceXL = 6 * base
+	lt.Space2XL = 8 * base
+
+	lt.CardPaddingLeft = 5 * base
+	lt.CardPaddingRight = 5 * base
+	lt.CardPaddingTop = 5 * base
+	lt.CardPaddingBottom = 5 * base
+	lt.CardIconSpacing = 2 * base
+	lt.CardHeaderPadding = int(math.Round(2.5 * float64(base)))
+
+	lt.DefaultGridGap = float64(2 * base)
+
+	return lt
+}
+
+// SpaceStep is a named step of the spacing scale
+type SpaceStep struct {
+	Name string // "xs", "s", "m", "l", "xl", "2xl"
+	Px   int
+}
+
+// Scale returns the spacing scale as named steps in ascending order (xs→2xl)
+func (lt *LayoutTokens) Scale() []SpaceStep {
+	return []SpaceStep{
+		{"xs", lt.SpaceXS},
+		{"s", lt.SpaceS},
+		{"m", lt.SpaceM},
+		{"l", lt.SpaceL},
+		{"xl", lt.SpaceXL},
+		{"2xl", lt.Space2XL},
+	}
+}
+
+// DefaultRootFontPx is the browser default root font size used for rem
+// conversion when none is given
+const DefaultRootFontPx = 16
+
+// SpaceRem returns the named spacing step ("xs" … "2xl") in rem relative to a
+// root font size of rootPx (DefaultRootFontPx if <= 0), e.g. "1rem" for m at
+// 16px. Unknown names return false.
+func (lt *LayoutTokens) SpaceRem(name string, rootPx int) (string, bool) {
+	for _, step := range lt.Scale() {
+		if step.Name == name {
+			return pxToRem(step.Px, rootPx), true
+		}
+	}
+	return "", false
+}
+
+// pxToRem formats px as rem relative to rootPx (DefaultRootFontPx if <= 0)
+func pxToRem(px, rootPx int) string {
+	if rootPx <= 0 {
+		rootPx = DefaultRootFontPx
+	}
+	return strconv.FormatFloat(float64(px)/float64(rootPx), 'f', -1, 64) + "rem"
+}
+
+// IsMonotonic reports whether each spacing step is at least as large as the
+// previous one
+func (lt *LayoutTokens) IsMonotonic() bool {
+	scale := lt.Scale()
+	for i := 1; i < len(scale); i++ {
+		if scale[i].Px < scale[i-1].Px {
+			return false
+		}
+	}
+	return true
+}
+
+// Breakpoint overrides the grid layout for viewports at least MinWidth px
+// wide. Zero GridColumns or GridGap keep the inherited value.
+type Breakpoint struct {
+	MinWidth    int
+	GridColumns int
+	GridGap     float64
+}
+
+// GridHeight returns the total height of a grid of itemCount items of
+// itemHeight laid out in columns, including DefaultGridGap between rows:
+// rows*itemHeight + (rows-1)*gap. Columns <= 0 fall back to
+// DefaultGridColumns (or 1 if that is unset); no items yields 0.
+func (lt *LayoutTokens) GridHeight(itemCount, columns int, itemHeight float64) float64 {
+	if itemCount <= 0 {
+		return 0
+	}
+	if columns <= 0 {
+		columns = lt.DefaultGridColumns
+	}
+	if columns <= 0 {
+		columns = 1
+	}
+	rows := (itemCount + columns - 1) / columns
+	return float64(rows)*itemHeight + float64(rows-1)*lt.DefaultGridGap
+}
+
+// CardHeight returns the height of a card of the given width: width divided
+// by CardAspectRatio when one is set, else the fixed StatCardHeight
+func (lt *LayoutTokens) CardHeight(width float64) float64 {
+	if lt.CardAspectRatio > 0 {
+		return width / lt.CardAspectRatio
+	}
+	return float64(lt.StatCardHeight)
+}
+
+// Radius returns the named corner radius in px: "none", "sm", "md", "lg",
+// "xl" or "full" (RadiusFullPx). Unknown names return false.
+func (lt *LayoutTokens) Radius(name string) (int, bool) {
+	switch name {
+	case "none":
+		return 0, true
+	case "sm":
+		return lt.RadiusSM, true
+	case "md":
+		return lt.RadiusMD, true
+	case "lg":
+		return lt.RadiusLG, true
+	case "xl":
+		return lt.RadiusXL, true
+	case "full":
+		return RadiusFullPx, true
+	}
+	return 0, false
+}
+
+// withRadiusMD returns a copy of the layout tokens with the radius scale
+// re-derived around md: sm = md/2, lg = 1.5×md, xl = 2×md
+func (lt *LayoutTokens) withRadiusMD(md int) *LayoutTokens {
+	if md < 0 {
+		md = 0
+	}
+	scaled := *lt
+	scaled.RadiusSM = int(math.Round(float64(md) / 2))
+	scaled.RadiusMD = md
+	scaled.RadiusLG = int(math.Round(1.5 * float64(md)))
+	scaled.RadiusXL = 2 * md
+	return &scaled
+}
+
+// RadiusFullPx is the pixel value stored in Radius for a fully rounded radius.
+// Both CSS border-radius and SVG rx/ry clamp it to half the box size, which
+// yields a pill (or a circle for square boxes).
+const RadiusFullPx = 9999
+
+// IsRadiusFull reports whether the tokens request fully rounded corners,
+// either via the "full" Radix radius or a radius at the RadiusFullPx sentinel
+func (dt *DesignTokens) IsRadiusFull() bool {
+	return dt.RadiusFull || dt.Radius >= RadiusFullPx
+}
+
+// RadiusFor returns the corner radius to draw for a box of the given size,
+// never exceeding half the shorter side. Use it instead of Radius in
+// renderer math so the "full" sentinel never overflows a shape.
+func (dt *DesignTokens) RadiusFor(width, height float64) float64 {
+	half := math.Min(width, height) / 2
+	if half < 0 {
+		return 0
+	}
+	if dt.IsRadiusFull() {
+		return half
+	}
+	return math.Min(float64(dt.Radius), half)
+}
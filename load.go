@@ -0,0 +1,168 @@
+package design
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadThemeFile reads a JSON theme file and returns the resolved tokens.
+// The file holds DesignTokens fields by name (case-insensitive), e.g.
+// {"theme": "brand", "mode": "dark", "color": "#E5E7EB", "backgroundLight": "#FFFFFF"}.
+// Fields not present keep the default theme's values, and the light/dark
+// variant matching Mode is applied to the base colors.
+func LoadThemeFile(path string) (*DesignTokens, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := DefaultTheme()
+	if err := json.Unmarshal(data, tokens); err != nil {
+		return nil, fmt.Errorf("parse theme file %s: %w", path, err)
+	}
+	tokens.densityDeclared = declaresField(data, "density")
+	if tokens.Layout == nil {
+		tokens.Layout = DefaultLayoutTokens()
+	}
+
+	switch tokens.Mode {
+	case "light":
+		return tokens.LightMode(), nil
+	case "dark":
+		return tokens.DarkMode(), nil
+	default:
+		return nil, fmt.Errorf("theme file %s: invalid mode %q", path, tokens.Mode)
+	}
+}
+
+// declaresField reports whether the JSON object in data has a top-level key
+// matching name case-insensitively, like encoding/json field matching
+func declaresField(data []byte, name string) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	for key := range fields {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadAndRegisterThemes loads every *.json theme file in dir and registers
+// each under its file name without extension (e.g. "brand.json" → "brand")
+func LoadAndRegisterThemes(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		tokens, err := LoadThemeFile(path)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		tokens.Theme = name
+		RegisterTheme(name, tokens)
+	}
+	return nil
+}
+
+// FromBase16 converts a base16 scheme (YAML with base00–base0F hex colors,
+// with or without "#") to tokens following the base16 styling guide:
+// base00 is the Background, base05 the text Color and base0D (functions,
+// links) the Accent, while base08 (red), base0A (yellow) and base0B (green)
+// become the Danger, Warning and Success colors. The theme name comes from
+// the "scheme" (or "name") key and the mode from the background's lightness.
+// Missing colors fall back to base06/base07 for text, base0C/base0E for the
+// accent, and otherwise to the default theme (or the best-contrast foreground
+// for text, and the built-in status colors). It fails only if the data
+// contains no valid base colors.
+func FromBase16(data []byte) (*DesignTokens, error) {
+	values := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.Trim(strings.TrimSpace(key), `"'`))
+		values[key] = base16Value(value)
+	}
+
+	colors := map[string]string{}
+	for key, value := range values {
+		if !strings.HasPrefix(key, "base") || value == "" {
+			continue
+		}
+		if _, err := parseTokenColor(paramColor(value)); err == nil {
+			colors[key] = NormalizeHexCase(paramColor(value))
+		}
+	}
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("base16 scheme: no valid base colors found")
+	}
+
+	first := func(keys ...string) string {
+		for _, k := range keys {
+			if c, ok := colors[k]; ok {
+				return c
+			}
+		}
+		return ""
+	}
+
+	tokens := DefaultTheme()
+	tokens.Theme = "base16"
+	for _, key := range []string{"scheme", "name"} {
+		if name := NormalizeThemeName(values[key]); name != "" {
+			tokens.Theme = name
+			break
+		}
+	}
+
+	if bg := first("base00"); bg != "" {
+		tokens.Background = bg
+	}
+	if fg := first("base05", "base06", "base07"); fg != "" {
+		tokens.Color = fg
+	} else {
+		tokens.Color = bestForeground(tokens.Background)
+	}
+	if accent := first("base0d", "base0c", "base0e"); accent != "" {
+		tokens.Accent = accent
+	}
+	tokens.Danger = first("base08")
+	tokens.Warning = first("base0a")
+	tokens.Success = first("base0b")
+
+	tokens.Mode = "dark"
+	if tokens.Luminosity() > pairMidGray {
+		tokens.Mode = "light"
+	}
+	return tokens, nil
+}
+
+// base16Value extracts a scalar YAML value: the contents of a quoted string,
+// or the text before an inline " #" comment
+func base16Value(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+		return strings.Trim(value, `"'`)
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,93 @@
+package design
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadedThemeDensityPreference(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"nodensity.json":   `{"mode": "light", "color": "#111111", "background": "#FFFFFF", "accent": "#0066FF"}`,
+		"comfortable.json": `{"mode": "light", "color": "#111111", "background": "#FFFFFF", "accent": "#0066FF", "Density": "comfortable"}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := LoadAndRegisterThemes(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	base := ResolveDesignTokens(map[string]string{"density": "compact"})
+	tests := []struct {
+		theme, want string
+	}{
+		{"nodensity", "compact"},
+		{"comfortable", "comfortable"},
+	}
+	for _, tt := range tests {
+		if got := ResolveWithBase(map[string]string{"theme": tt.theme}, base).Density; got != tt.want {
+			t.Errorf("ResolveWithBase(theme=%s) Density = %q, want %q", tt.theme, got, tt.want)
+		}
+	}
+}
+
+func TestFromBase16(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "base16", "nord.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := FromBase16(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		field, got, want string
+	}{
+		{"Theme", tokens.Theme, "nord"},
+		{"Mode", tokens.Mode, "dark"},
+		{"Background", tokens.Background, "#2E3440"},
+		{"Color", tokens.Color, "#E5E9F0"},
+		{"Accent", tokens.Accent, "#81A1C1"},
+		{"Danger", tokens.Danger, "#BF616A"},
+		{"Warning", tokens.Warning, "#EBCB8B"},
+		{"Success", tokens.Success, "#A3BE8C"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.field, tt.got, tt.want)
+		}
+	}
+
+	if !tokens.Capabilities().HasSemanticColors {
+		t.Error("Capabilities().HasSemanticColors = false, want true")
+	}
+	withoutDanger := *tokens
+	withoutDanger.Danger = ""
+	got, _ := tokens.BadgeColors("danger")
+	builtin, _ := withoutDanger.BadgeColors("danger")
+	if got == builtin {
+		t.Errorf(`BadgeColors("danger") background %q ignores the scheme's red`, got)
+	}
+}
+
+func TestFromBase16Minimal(t *testing.T) {
+	tokens, err := FromBase16([]byte("base00: FFFFFF\nbase0D: '0066ff'\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens.Mode != "light" || tokens.Accent != "#0066FF" {
+		t.Errorf("Mode, Accent = %q, %q, want light, #0066FF", tokens.Mode, tokens.Accent)
+	}
+	if tokens.Danger != "" || tokens.Warning != "" || tokens.Success != "" {
+		t.Errorf("status colors = %q, %q, %q, want empty", tokens.Danger, tokens.Warning, tokens.Success)
+	}
+
+	if _, err := FromBase16([]byte("# no colors\nscheme: empty\n")); err == nil {
+		t.Error("FromBase16 without base colors = nil error, want an error")
+	}
+}
@@ -0,0 +1,132 @@
+package design
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/SCKelemen/color"
+)
+
+// Material 3 key-palette chromas and hues (tertiary is relative to the source hue)
+const (
+	m3PrimaryMinChroma = 48
+	m3SecondaryChroma  = 16
+	m3TertiaryChroma   = 24
+	m3TertiaryHueShift = 60
+	m3NeutralChroma    = 4
+	m3NeutralVarChroma = 8
+	m3ErrorHue         = 25
+	m3ErrorChroma      = 84
+)
+
+// tonalPalette is a hue/chroma pair that yields a color for any M3 tone
+// (0 = black, 100 = white)
+type tonalPalette struct {
+	Hue, Chroma float64
+}
+
+// tone returns the palette's color at tone t as hex. Tones are CIELAB L*, as
+// in M3's HCT; chroma is reduced until the color fits in sRGB.
+func (p tonalPalette) tone(t float64) string {
+	lo, hi := 0.0, p.Chroma
+	for i := 0; i < 16; i++ {
+		mid := (lo + hi) / 2
+		if lchInGamut(t, mid, p.Hue) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return colorToHex(color.NewLCH(t, lo, p.Hue, 1))
+}
+
+// lchInGamut reports whether a CIELCH color survives an sRGB round trip
+func lchInGamut(l, c, h float64) bool {
+	lch := color.NewLCH(l, c, h, 1)
+	r, g, b, _ := lch.RGBA()
+	back := color.ToLAB(color.RGB(r, g, b))
+
+	rad := h * math.Pi / 180
+	dl, da, db := back.L-l, back.A-c*math.Cos(rad), back.B-c*math.Sin(rad)
+	return math.Sqrt(dl*dl+da*da+db*db) < 0.5
+}
+
+// m3Role maps an M3 color role to a palette tone, per mode
+type m3Role struct {
+	Name    string
+	Palette string
+	Light   float64
+	Dark    float64
+}
+
+// m3Roles follows the Material 3 baseline scheme role-to-tone mapping
+var m3Roles = []m3Role{
+	{"primary", "primary", 40, 80},
+	{"onPrimary", "primary", 100, 20},
+	{"primaryContainer", "primary", 90, 30},
+	{"onPrimaryContainer", "primary", 10, 90},
+	{"inversePrimary", "primary", 80, 40},
+	{"secondary", "secondary", 40, 80},
+	{"onSecondary", "secondary", 100, 20},
+	{"secondaryContainer", "secondary", 90, 30},
+	{"onSecondaryContainer", "secondary", 10, 90},
+	{"tertiary", "tertiary", 40, 80},
+	{"onTertiary", "tertiary", 100, 20},
+	{"tertiaryContainer", "tertiary", 90, 30},
+	{"onTertiaryContainer", "tertiary", 10, 90},
+	{"error", "error", 40, 80},
+	{"onError", "error", 100, 20},
+	{"errorContainer", "error", 90, 30},
+	{"onErrorContainer", "error", 10, 90},
+	{"background", "neutral", 99, 10},
+	{"onBackground", "neutral", 10, 90},
+	{"surface", "neutral", 99, 10},
+	{"onSurface", "neutral", 10, 90},
+	{"inverseSurface", "neutral", 20, 90},
+	{"inverseOnSurface", "neutral", 95, 20},
+	{"surfaceVariant", "neutralVariant", 90, 30},
+	{"onSurfaceVariant", "neutralVariant", 30, 80},
+	{"outline", "neutralVariant", 50, 60},
+	{"outlineVariant", "neutralVariant", 80, 30},
+	{"shadow", "neutral", 0, 0},
+	{"scrim", "neutral", 0, 0},
+}
+
+// ToMaterial3 generates Material 3 color role tokens (primary, onPrimary,
+// primaryContainer, surface, onSurface, ...) using the accent as the source
+// color. Primary, secondary, tertiary and error palettes follow M3's key
+// chroma rules; the neutral palettes take the background's hue, with chroma
+// capped at M3's neutral values so an achromatic background yields gray
+// surfaces. The light or dark scheme is chosen by Mode. Tones are CIELAB
+// lightness, the closest available match for HCT tone.
+func (dt *DesignTokens) ToMaterial3() (map[string]string, error) {
+	accent, err := parseTokenColor(dt.Accent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid accent %q: %w", dt.Accent, err)
+	}
+	background, err := parseTokenColor(dt.Background)
+	if err != nil {
+		return nil, fmt.Errorf("invalid background %q: %w", dt.Background, err)
+	}
+
+	source := color.ToLCH(accent)
+	surface := color.ToLCH(background)
+	palettes := map[string]tonalPalette{
+		"primary":        {source.H, math.Max(source.C, m3PrimaryMinChroma)},
+		"secondary":      {source.H, m3SecondaryChroma},
+		"tertiary":       {math.Mod(source.H+m3TertiaryHueShift, 360), m3TertiaryChroma},
+		"error":          {m3ErrorHue, m3ErrorChroma},
+		"neutral":        {surface.H, math.Min(surface.C, m3NeutralChroma)},
+		"neutralVariant": {surface.H, math.Min(surface.C*2, m3NeutralVarChroma)},
+	}
+
+	roles := make(map[string]string, len(m3Roles))
+	for _, r := range m3Roles {
+		t := r.Light
+		if dt.Mode == "dark" {
+			t = r.Dark
+		}
+		roles[r.Name] = palettes[r.Palette].tone(t)
+	}
+	return roles, nil
+}
@@ -0,0 +1,161 @@
+package design
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// lockedParams are the query parameters still honored with locked=true:
+// theme and mode selection, plus the reduced transparency accessibility
+// preference
+var lockedParams = []string{"theme", "mode", "prefer", "locked", "reduced_transparency"}
+
+// lockedQueryParams returns params unchanged unless locked=true, in which
+// case every override but lockedParams is dropped so untrusted query strings
+// cannot alter a curated theme
+func lockedQueryParams(params map[string]string) map[string]string {
+	if params["locked"] != "true" {
+		return params
+	}
+	kept := make(map[string]string, len(lockedParams))
+	for _, key := range lockedParams {
+		if v, ok := params[key]; ok {
+			kept[key] = v
+		}
+	}
+	return kept
+}
+
+// ErrUnknownQueryParam is returned (wrapped) by strict resolution when a
+// query parameter is not recognized
+var ErrUnknownQueryParam = errors.New("unknown query parameter")
+
+// ErrThemeNotAllowed is returned (wrapped) by strict resolution when the
+// theme param is outside the SetAllowedThemes allowlist
+var ErrThemeNotAllowed = errors.New("theme not allowed")
+
+// acceptedQueryParams lists every query parameter understood by
+// ResolveDesignTokens and ResolveMotionTokens
+var acceptedQueryParams = []string{
+	// Theme and mode
+	"theme",
+	"mode",
+	"prefer",
+	"locked",
+
+	// Colors (single or LIGHT/DARK format) and legacy variants
+	"color",
+	"color_light",
+	"color_dark",
+	"background",
+	"background_light",
+	"background_dark",
+	"accent",
+	"accent_light",
+	"accent_dark",
+	"accent_blend",
+	"accent_l",
+	"brand",
+	"selection_bg",
+	"selection_fg",
+	"link",
+	"solarized_accent",
+
+	// Density and effects
+	"density",
+	"density_scale",
+	"grid_base",
+	"card_aspect",
+	"glass",
+	"glass_blur",
+	"reduced_transparency",
+	"flat",
+	"max_chroma",
+	"min_accent_chroma",
+	"oled",
+	"tint",
+	"tint_light",
+	"tint_dark",
+
+	// Radix UI tokens
+	"accentColor",
+	"grayColor",
+	"radius",
+	"scaling",
+	"scale_radius",
+
+	// Motion
+	"motion",
+}
+
+// AcceptedQueryParams returns the names of all recognized query parameters
+func AcceptedQueryParams() []string {
+	params := make([]string, len(acceptedQueryParams))
+	copy(params, acceptedQueryParams)
+	return params
+}
+
+// isAcceptedQueryParam reports whether name is a recognized query parameter
+func isAcceptedQueryParam(name string) bool {
+	for _, p := range acceptedQueryParams {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveDesignTokensStrictWithUnknownCheck resolves design tokens like
+// ResolveDesignTokens, but returns an error wrapping ErrUnknownQueryParam if
+// any parameter is not in AcceptedQueryParams (e.g. a typo like "acent"), or
+// wrapping ErrThemeNotAllowed if the theme is outside SetAllowedThemes.
+// ResolveDesignTokens itself keeps ignoring unknown parameters and falls back
+// to the default for disallowed themes.
+func ResolveDesignTokensStrictWithUnknownCheck(queryParams map[string]string) (*DesignTokens, error) {
+	var unknown []string
+	for name := range queryParams {
+		if !isAcceptedQueryParam(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownQueryParam, strings.Join(unknown, ", "))
+	}
+	if theme := NormalizeThemeName(queryParams["theme"]); !isThemeAllowed(theme) {
+		return nil, fmt.Errorf("%w: %s", ErrThemeNotAllowed, theme)
+	}
+	return ResolveDesignTokens(queryParams), nil
+}
+
+// ResolveDesignTokensFromString resolves design tokens from a compact
+// delimited config string such as a cookie value ("theme=nord;mode=dark;accent=f00").
+// Pairs are split on sep and each pair on kv, defaulting to ";" and "=" when
+// empty. Keys and values are trimmed, empty or key-only pairs are ignored,
+// and a repeated key keeps its last value.
+func ResolveDesignTokensFromString(s, sep, kv string) *DesignTokens {
+	return ResolveDesignTokens(parseParamString(s, sep, kv))
+}
+
+// parseParamString parses a delimited key=value string into a param map
+func parseParamString(s, sep, kv string) map[string]string {
+	if sep == "" {
+		sep = ";"
+	}
+	if kv == "" {
+		kv = "="
+	}
+
+	params := map[string]string{}
+	for _, pair := range strings.Split(s, sep) {
+		key, value, ok := strings.Cut(pair, kv)
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		params[key] = strings.TrimSpace(value)
+	}
+	return params
+}
@@ -0,0 +1,45 @@
+package design
+
+import "fmt"
+
+// ColorCMYK returns the resolved text color as CMYK components in [0, 1]
+func (dt *DesignTokens) ColorCMYK() (c, m, y, k float64, err error) {
+	return toCMYK(dt.Color)
+}
+
+// BackgroundCMYK returns the resolved background as CMYK components in [0, 1]
+func (dt *DesignTokens) BackgroundCMYK() (c, m, y, k float64, err error) {
+	return toCMYK(dt.Background)
+}
+
+// AccentCMYK returns the resolved accent as CMYK components in [0, 1]
+func (dt *DesignTokens) AccentCMYK() (c, m, y, k float64, err error) {
+	return toCMYK(dt.Accent)
+}
+
+// toCMYK converts a plain color to device CMYK using the standard naive
+// (profile-free) formula. Alpha is ignored.
+func toCMYK(value string) (c, m, y, k float64, err error) {
+	parsed, err := parseTokenColor(value)
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("cmyk: %w", err)
+	}
+
+	r, g, b, _ := parsed.RGBA()
+	maxChannel := r
+	if g > maxChannel {
+		maxChannel = g
+	}
+	if b > maxChannel {
+		maxChannel = b
+	}
+
+	k = 1 - maxChannel
+	if k >= 1 {
+		return 0, 0, 0, 1, nil
+	}
+	c = (1 - r - k) / (1 - k)
+	m = (1 - g - k) / (1 - k)
+	y = (1 - b - k) / (1 - k)
+	return c, m, y, k, nil
+}
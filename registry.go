@@ -0,0 +1,167 @@
+package design
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	registeredThemesMu sync.RWMutex
+	registeredThemes   = map[string]map[string]map[string]string{}
+
+	themeAliasesMu sync.RWMutex
+	themeAliases   = map[string]string{
+		"gruv":  "gruvbox",
+		"tokyo": "tokyonight",
+		"solar": "solarized",
+	}
+
+	allowedThemesMu sync.RWMutex
+	allowedThemes   map[string]bool
+)
+
+// SetAllowedThemes restricts the themes ResolveDesignTokens accepts to names
+// (normalized like NormalizeThemeName; mode suffixes are implied, so "nord"
+// also allows "nord-dark"). A disallowed theme param falls back to the
+// default tokens, and ResolveDesignTokensStrictWithUnknownCheck rejects it
+// with ErrThemeNotAllowed. An empty list allows every theme. Safe for
+// concurrent use.
+func SetAllowedThemes(names []string) {
+	allowedThemesMu.Lock()
+	defer allowedThemesMu.Unlock()
+
+	if len(names) == 0 {
+		allowedThemes = nil
+		return
+	}
+	allowedThemes = make(map[string]bool, len(names))
+	for _, name := range names {
+		base, _ := splitThemeMode(NormalizeThemeName(name))
+		allowedThemes[base] = true
+	}
+}
+
+// isThemeAllowed reports whether a normalized theme name passes the
+// SetAllowedThemes allowlist; no theme (the default) always passes
+func isThemeAllowed(theme string) bool {
+	allowedThemesMu.RLock()
+	defer allowedThemesMu.RUnlock()
+
+	if allowedThemes == nil || theme == "" {
+		return true
+	}
+	base, _ := splitThemeMode(theme)
+	return allowedThemes[base]
+}
+
+// NormalizeThemeName canonicalizes a theme name before lookup: it lowercases,
+// treats "_" and spaces like "-", removes separators from the base name
+// ("rose-pine", "rose_pine" → "rosepine") and resolves registered aliases,
+// while keeping a "-light"/"-dark" mode suffix intact
+// (e.g. "Tokyo_Night-dark" → "tokyonight-dark").
+func NormalizeThemeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer("_", "-", " ", "-").Replace(name)
+
+	base, mode := splitThemeMode(name)
+	base = strings.ReplaceAll(base, "-", "")
+
+	themeAliasesMu.RLock()
+	if canonical, ok := themeAliases[base]; ok {
+		base = canonical
+	}
+	themeAliasesMu.RUnlock()
+
+	if mode != "" && base != "" {
+		return base + "-" + mode
+	}
+	return base
+}
+
+// RegisterThemeAlias maps an alternative theme name to a canonical one
+// (e.g. "gruv" → "gruvbox"). Both names are normalized for separators.
+// Safe for concurrent use.
+func RegisterThemeAlias(alias, canonical string) {
+	strip := func(s string) string {
+		s = strings.ToLower(strings.TrimSpace(s))
+		return strings.NewReplacer("_", "", " ", "", "-", "").Replace(s)
+	}
+	alias, canonical = strip(alias), strip(canonical)
+	if alias == "" || canonical == "" || alias == canonical {
+		return
+	}
+
+	themeAliasesMu.Lock()
+	defer themeAliasesMu.Unlock()
+	themeAliases[alias] = canonical
+}
+
+// RegisterTheme makes a theme available by name to ResolveDesignTokens
+// (including "-light"/"-dark" suffix handling). The theme's colors for each
+// mode come from its light/dark variants, falling back to the base colors for
+// the tokens' own Mode; a theme without variants is single-mode. Registered
+// themes take precedence over built-in themes of the same name.
+// Safe for concurrent use.
+func RegisterTheme(name string, tokens *DesignTokens) {
+	name = NormalizeThemeName(name)
+	if name == "" || tokens == nil {
+		return
+	}
+
+	modes := themeModesFromTokens(tokens)
+	if len(modes) == 0 {
+		return
+	}
+
+	registeredThemesMu.Lock()
+	defer registeredThemesMu.Unlock()
+	registeredThemes[name] = modes
+}
+
+// lookupTheme returns the per-mode colors of a registered or built-in theme
+func lookupTheme(name string) (map[string]map[string]string, bool) {
+	registeredThemesMu.RLock()
+	themeMap, ok := registeredThemes[name]
+	registeredThemesMu.RUnlock()
+	if ok {
+		return themeMap, true
+	}
+
+	themeMap, ok = builtinThemes[name]
+	return themeMap, ok
+}
+
+// themeModesFromTokens converts tokens into the per-mode theme definition
+// shape used by applyTheme
+func themeModesFromTokens(dt *DesignTokens) map[string]map[string]string {
+	variants := map[string][3]string{
+		"light": {dt.ColorLight, dt.BackgroundLight, dt.AccentLight},
+		"dark":  {dt.ColorDark, dt.BackgroundDark, dt.AccentDark},
+	}
+
+	modes := map[string]map[string]string{}
+	for mode, v := range variants {
+		hasVariant := v[0] != "" || v[1] != "" || v[2] != ""
+		if !hasVariant && dt.Mode != mode {
+			continue
+		}
+
+		pick := func(variant, base string) string {
+			if variant != "" {
+				return variant
+			}
+			return base
+		}
+		modes[mode] = map[string]string{
+			"color":      pick(v[0], dt.Color),
+			"background": pick(v[1], dt.Background),
+			"accent":     pick(v[2], dt.Accent),
+		}
+		// Only a declared density is a preference; the default comfortable
+		// must not override a compact base
+		if isNamedDensity(dt.Density) && (dt.densityDeclared || dt.Density != "comfortable") {
+			modes[mode]["density"] = dt.Density
+		}
+	}
+	return modes
+}
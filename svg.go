@@ -0,0 +1,107 @@
+package design
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/SCKelemen/color"
+)
+
+// Gradient directions for AccentGradientDef
+const (
+	GradientHorizontal = "horizontal" // Left to right
+	GradientVertical   = "vertical"   // Top to bottom
+	GradientDiagonal   = "diagonal"   // Top-left to bottom-right
+)
+
+// AccentGradientDef returns an SVG <linearGradient> element, ready for <defs>,
+// interpolating between the AccentGradientStops. Direction is "horizontal"
+// (default), "vertical" or "diagonal".
+func (dt *DesignTokens) AccentGradientDef(id string, direction string) string {
+	x2, y2 := "1", "0"
+	switch direction {
+	case GradientVertical:
+		x2, y2 = "0", "1"
+	case GradientDiagonal:
+		x2, y2 = "1", "1"
+	}
+
+	start, end := dt.AccentGradientStops()
+
+	return fmt.Sprintf(`<linearGradient id="%s" x1="0" y1="0" x2="%s" y2="%s">`+
+		`<stop offset="0%%" stop-color="%s"/>`+
+		`<stop offset="100%%" stop-color="%s"/>`+
+		`</linearGradient>`,
+		html.EscapeString(id), x2, y2, html.EscapeString(start), html.EscapeString(end))
+}
+
+// defaultFaviconSize is the FaviconSVG edge length used for sizes <= 0
+const defaultFaviconSize = 32
+
+// FaviconSVG returns a tiny standalone square SVG filled with the background
+// and a centered accent dot, suitable as a data-URI favicon. Colors are
+// written out directly since favicons render without the page's CSS.
+func (dt *DesignTokens) FaviconSVG(size int) string {
+	if size <= 0 {
+		size = defaultFaviconSize
+	}
+	half := float64(size) / 2
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+
+		`<rect width="%d" height="%d" fill="%s"/>`+
+		`<circle cx="%g" cy="%g" r="%g" fill="%s"/>`+
+		`</svg>`,
+		size, size, size, size,
+		size, size, html.EscapeString(dt.Background),
+		half, half, half/2, html.EscapeString(dt.Accent))
+}
+
+// InlineStyleMap returns the tokens as literal values keyed by SVG role, for
+// renderers that set presentation attributes directly on elements: "fill"
+// (background), "stroke" (a subtle border), "text" (text fill), "accent",
+// "accent-text" (text on accent fills), "font-family" and "rx" (corner
+// radius in px; SVG clamps the full-radius sentinel to half the box).
+// GitHub strips <style> and CSS variables from README SVGs, so this is the
+// way to render them styled there.
+func (dt *DesignTokens) InlineStyleMap() map[string]string {
+	rx := dt.Radius
+	if dt.IsRadiusFull() {
+		rx = RadiusFullPx
+	}
+	return map[string]string{
+		"fill":        dt.Background,
+		"stroke":      mixColors(dt.Background, dt.Color, 0.12),
+		"text":        dt.Color,
+		"accent":      dt.Accent,
+		"accent-text": bestForeground(dt.Accent),
+		"font-family": dt.FontFamily,
+		"rx":          strconv.Itoa(rx),
+	}
+}
+
+// AccentColorMatrix returns an feColorMatrix values string that recolors a
+// monochrome icon to the accent: white maps to the accent, black stays black,
+// grays become accent shades and alpha is preserved. Channels are linear
+// RGB, matching the default color-interpolation-filters, e.g.
+// <filter id="tint"><feColorMatrix type="matrix" values="..."/></filter>.
+func (dt *DesignTokens) AccentColorMatrix() string {
+	accent, err := parseTokenColor(dt.Accent)
+	if err != nil {
+		accent = color.RGB(1, 1, 1)
+	}
+	r, g, b, _ := accent.RGBA()
+
+	format := func(v float64) string {
+		return strconv.FormatFloat(math.Round(v*10000)/10000, 'f', -1, 64)
+	}
+	var rows []string
+	for _, channel := range []float64{r, g, b} {
+		linear := srgbToLinear(channel)
+		rows = append(rows, fmt.Sprintf("%s %s %s 0 0",
+			format(linear*0.2126), format(linear*0.7152), format(linear*0.0722)))
+	}
+	rows = append(rows, "0 0 0 1 0")
+	return strings.Join(rows, " ")
+}
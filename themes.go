@@ -1,5 +1,11 @@
 package design
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // DefaultTheme returns the default design tokens
 func DefaultTheme() *DesignTokens {
 	return &DesignTokens{
@@ -13,6 +19,8 @@ func DefaultTheme() *DesignTokens {
 		Density:    "comfortable",
 		Mode:       "dark",
 		Layout:     DefaultLayoutTokens(),
+
+		DensityScale: DensityScaleComfortable,
 	}
 }
 
@@ -29,6 +37,8 @@ func MidnightTheme() *DesignTokens {
 		Density:    "comfortable",
 		Mode:       "dark",
 		Layout:     DefaultLayoutTokens(),
+
+		DensityScale: DensityScaleComfortable,
 	}
 }
 
@@ -45,6 +55,8 @@ func NordTheme() *DesignTokens {
 		Density:    "comfortable",
 		Mode:       "dark",
 		Layout:     DefaultLayoutTokens(),
+
+		DensityScale: DensityScaleComfortable,
 	}
 }
 
@@ -61,6 +73,8 @@ func PaperTheme() *DesignTokens {
 		Density:    "comfortable",
 		Mode:       "light",
 		Layout:     DefaultLayoutTokens(),
+
+		DensityScale: DensityScaleComfortable,
 	}
 }
 
@@ -76,10 +90,49 @@ func WrappedTheme() *DesignTokens {
 		Padding:    16,
 		Density:    "comfortable",
 		Mode:       "dark",
+		Layout:     DefaultLayoutTokens().withRadiusMD(20),
+
+		DensityScale: DensityScaleComfortable,
+	}
+}
+
+// SolarizedTheme returns the Solarized theme (dark mode)
+func SolarizedTheme() *DesignTokens {
+	return &DesignTokens{
+		Theme:      "solarized",
+		Color:      "#839496",
+		Background: "#002B36",
+		Accent:     "#268BD2",
+		FontFamily: "system-ui",
+		Radius:     16,
+		Padding:    16,
+		Density:    "comfortable",
+		Mode:       "dark",
 		Layout:     DefaultLayoutTokens(),
+
+		DensityScale: DensityScaleComfortable,
 	}
 }
 
+// solarizedAccents are the eight accent colors defined by the Solarized palette
+var solarizedAccents = map[string]string{
+	"yellow":  "#B58900",
+	"orange":  "#CB4B16",
+	"red":     "#DC322F",
+	"magenta": "#D33682",
+	"violet":  "#6C71C4",
+	"blue":    "#268BD2",
+	"cyan":    "#2AA198",
+	"green":   "#859900",
+}
+
+// SolarizedAccent returns the hex value of a named Solarized accent
+// ("yellow", "orange", "red", "magenta", "violet", "blue", "cyan", "green")
+func SolarizedAccent(name string) (string, bool) {
+	accent, ok := solarizedAccents[strings.ToLower(name)]
+	return accent, ok
+}
+
 // CustomTheme creates a theme from query parameters
 func CustomTheme(params map[string]string) *DesignTokens {
 	return ResolveDesignTokens(params)
@@ -100,6 +153,9 @@ func (dt *DesignTokens) LightMode() *DesignTokens {
 	if dt.AccentLight != "" {
 		lightTokens.Accent = dt.AccentLight
 	}
+	if dt.BrandLight != "" {
+		lightTokens.Brand = dt.BrandLight
+	}
 
 	return &lightTokens
 }
@@ -119,6 +175,52 @@ func (dt *DesignTokens) DarkMode() *DesignTokens {
 	if dt.AccentDark != "" {
 		darkTokens.Accent = dt.AccentDark
 	}
+	if dt.BrandDark != "" {
+		darkTokens.Brand = dt.BrandDark
+	}
 
 	return &darkTokens
 }
+
+// PreviewMatrix resolves every built-in theme in each mode it defines, keyed
+// by theme name and then mode ("light", "dark"), e.g. for rendering a
+// documentation gallery of all theme × mode combinations. SetAllowedThemes
+// does not apply.
+func PreviewMatrix() map[string]map[string]*DesignTokens {
+	matrix := map[string]map[string]*DesignTokens{}
+	for name, modes := range builtinThemes {
+		matrix[name] = map[string]*DesignTokens{}
+		for mode := range modes {
+			matrix[name][mode] = resolveDesignTokens(map[string]string{"theme": name + "-" + mode}, nil, false)
+		}
+	}
+	return matrix
+}
+
+// AllThemesCSS returns one stylesheet with a
+// [data-theme="name"][data-mode="mode"] block (see ToCSSScoped) for every
+// built-in theme in each mode it defines, for instant client-side switching.
+// Blocks are sorted by theme name, then mode. Like PreviewMatrix, it ignores
+// SetAllowedThemes.
+func AllThemesCSS() string {
+	matrix := PreviewMatrix()
+	names := make([]string, 0, len(matrix))
+	for name := range matrix {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		modes := make([]string, 0, len(matrix[name]))
+		for mode := range matrix[name] {
+			modes = append(modes, mode)
+		}
+		sort.Strings(modes)
+		for _, mode := range modes {
+			selector := fmt.Sprintf(`[data-theme="%s"][data-mode="%s"]`, name, mode)
+			b.WriteString(matrix[name][mode].ToCSSScoped(selector))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package design
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPreviewMatrixIgnoresAllowedThemes(t *testing.T) {
+	SetAllowedThemes([]string{"nord"})
+	defer SetAllowedThemes(nil)
+
+	paper := PreviewMatrix()["paper"]["light"]
+	if paper.Theme != "paper" || paper.Mode != "light" {
+		t.Fatalf("PreviewMatrix paper-light = %s-%s, want paper-light", paper.Theme, paper.Mode)
+	}
+	if want := builtinThemes["paper"]["light"]["background"]; paper.Background != want {
+		t.Errorf("paper-light Background = %s, want %s", paper.Background, want)
+	}
+
+	block := paper.ToCSSScoped(`[data-theme="paper"][data-mode="light"]`)
+	if !strings.Contains(AllThemesCSS(), block) {
+		t.Error("AllThemesCSS is missing the paper-light block")
+	}
+}
@@ -2,6 +2,7 @@ package design
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -16,9 +17,26 @@ type DesignTokens struct {
 	Accent     string
 	FontFamily string
 	Radius     int
-	Padding    int
-	Density    string // "compact" or "comfortable"
-	Mode       string // "light" or "dark"
+	RadiusFull bool // Fully rounded (pill/circle) intent; Radius holds RadiusFullPx
+	// RadiusExplicit records that Radius was set numerically by the caller
+	// (radius=12) rather than defaulted or derived from a Radix token
+	RadiusExplicit bool
+	Padding        int
+	Density        string // "compact" or "comfortable"
+	Mode           string // "light" or "dark"
+
+	// DensityScale is the continuous layout spacing multiplier (1.0 = comfortable)
+	DensityScale float64
+
+	// BackdropBlur is the backdrop blur radius in px for glass backgrounds (0 = none)
+	BackdropBlur int
+
+	// Flat disables depth effects: shadows emit as none and gradients
+	// collapse to the solid accent (see Flatten)
+	Flat bool
+
+	// AccentBlend is the mix-blend-mode for accent overlays ("multiply", "screen", "overlay")
+	AccentBlend string
 
 	// Light/dark variant colors (if specified, override base colors based on mode)
 	ColorLight      string
@@ -28,6 +46,27 @@ type DesignTokens struct {
 	AccentLight     string
 	AccentDark      string
 
+	// Brand color, distinct from the UI accent. Never adjusted by theme
+	// resolution or color transforms; only the user's own variants apply.
+	Brand      string
+	BrandLight string
+	BrandDark  string
+
+	// Text selection (::selection) overrides; empty derives them from the
+	// accent (see SelectionColors)
+	SelectionBackground string
+	SelectionColor      string
+
+	// Link is the hyperlink color override; empty derives it from the accent
+	// (see LinkColors)
+	Link string
+
+	// Semantic status color overrides (e.g. from a base16 scheme); empty
+	// uses the built-in palette (see BadgeColors)
+	Success string
+	Warning string
+	Danger  string
+
 	// Radix UI theme tokens
 	RadixAccentColor string // "pink", "blue", "green", etc.
 	RadixGrayColor   string // "mauve", "slate", "gray", etc.
@@ -36,6 +75,14 @@ type DesignTokens struct {
 
 	// Layout configuration
 	Layout *LayoutTokens
+
+	// themeExplicit records that a theme param was given, so an explicit
+	// "default" can be told apart from no theme at all
+	themeExplicit bool
+
+	// densityDeclared records that a loaded theme file set density, so a
+	// declared "comfortable" is kept as the theme's preferred density
+	densityDeclared bool
 }
 
 // LayoutTokens represents spacing and dimension configuration
@@ -59,14 +106,29 @@ type LayoutTokens struct {
 	CardHeaderPadding int // Padding for header items
 
 	// Component heights
-	StatCardHeight      int // Height for stat cards without trend
-	StatCardHeightTrend int // Height for stat cards with trend graph
-	TrendGraphMinHeight int // Minimum height for trend graphs
+	StatCardHeight      int     // Height for stat cards without trend
+	StatCardHeightTrend int     // Height for stat cards with trend graph
+	TrendGraphMinHeight int     // Minimum height for trend graphs
+	CardAspectRatio     float64 // Card width / height; 0 keeps the fixed StatCardHeight
 
 	// Grid defaults
 	DefaultGridGap     float64 // Default gap between grid items
 	DefaultGridWidth   float64 // Default grid container width
 	DefaultGridColumns int     // Default number of columns
+
+	// Responsive grid overrides, applied from each MinWidth up (see ToCSS)
+	Breakpoints []Breakpoint
+
+	// Corner radius scale; "none" (0) and "full" (RadiusFullPx) are implicit.
+	// RadiusMD tracks DesignTokens.Radius when resolving.
+	RadiusSM int // 8px
+	RadiusMD int // 16px
+	RadiusLG int // 24px
+	RadiusXL int // 32px
+
+	// Typography metrics (vary with density)
+	LineHeight float64 // Unitless line-height multiplier (1.5 comfortable, 1.3 compact)
+	FontScale  float64 // Font size multiplier (1.0 comfortable)
 }
 
 // DefaultLayoutTokens returns the default layout token values
@@ -99,12 +161,119 @@ func DefaultLayoutTokens() *LayoutTokens {
 		DefaultGridGap:     8.0,
 		DefaultGridWidth:   1000.0,
 		DefaultGridColumns: 3,
+
+		// Radius scale
+		RadiusSM: 8,
+		RadiusMD: 16,
+		RadiusLG: 24,
+		RadiusXL: 32,
+
+		// Typography metrics
+		LineHeight: LineHeightComfortable,
+		FontScale:  1.0,
+	}
+}
+
+// Density scale bounds and named shortcuts
+const (
+	DensityScaleCompact     = 0.75
+	DensityScaleComfortable = 1.0
+	minDensityScale         = 0.5
+	maxDensityScale         = 1.5
+)
+
+// Line heights for the named density levels
+const (
+	LineHeightCompact     = 1.3
+	LineHeightComfortable = 1.5
+)
+
+// typographyForDensityScale interpolates line-height and font scale for a
+// density scale: compact (0.75) → 1.3 / 0.9, comfortable (1.0) → 1.5 / 1.0
+func typographyForDensityScale(scale float64) (lineHeight, fontScale float64) {
+	t := (scale - DensityScaleComfortable) / (DensityScaleComfortable - DensityScaleCompact)
+	lineHeight = LineHeightComfortable + t*(LineHeightComfortable-LineHeightCompact)
+	lineHeight = math.Max(1.2, math.Min(1.7, lineHeight))
+	fontScale = 1.0 + t*0.1
+	return math.Round(lineHeight*100) / 100, math.Round(fontScale*100) / 100
+}
+
+// DefaultLayoutTokensScaled returns the default layout tokens with spacing
+// multiplied by scale. Scale is clamped to [0.5, 1.5] and every scaled
+// spacing value is floored at 1px.
+func DefaultLayoutTokensScaled(scale float64) *LayoutTokens {
+	return DefaultLayoutTokens().scaledSpacing(scale)
+}
+
+// scaledSpacing returns a copy of the layout tokens with spacing values
+// (scale steps, card paddings and grid gap) multiplied by a density scale
+func (lt *LayoutTokens) scaledSpacing(scale float64) *LayoutTokens {
+	scale = clampDensityScale(scale)
+	scaled := *lt
+	if scale == 1.0 {
+		return &scaled
+	}
+
+	scaleInt := func(v int) int {
+		s := roundScaled(float64(v) * scale)
+		if s < 1 {
+			return 1
+		}
+		return s
+	}
+
+	scaled.SpaceXS = scaleInt(lt.SpaceXS)
+	scaled.SpaceS = scaleInt(lt.SpaceS)
+	scaled.SpaceM = scaleInt(lt.SpaceM)
+	scaled.SpaceL = scaleInt(lt.SpaceL)
+	scaled.SpaceXL = scaleInt(lt.SpaceXL)
+	scaled.Space2XL = scaleInt(lt.Space2XL)
+
+	scaled.CardPaddingLeft = scaleInt(lt.CardPaddingLeft)
+	scaled.CardPaddingRight = scaleInt(lt.CardPaddingRight)
+	scaled.CardPaddingTop = scaleInt(lt.CardPaddingTop)
+	scaled.CardPaddingBottom = scaleInt(lt.CardPaddingBottom)
+	scaled.CardIconSpacing = scaleInt(lt.CardIconSpacing)
+	scaled.CardHeaderPadding = scaleInt(lt.CardHeaderPadding)
+
+	scaled.DefaultGridGap = math.Max(1, float64(roundScaled(lt.DefaultGridGap*scale)))
+
+	scaled.LineHeight, scaled.FontScale = typographyForDensityScale(scale)
+
+	return &scaled
+}
+
+// clampDensityScale limits a density scale to the supported range
+func clampDensityScale(scale float64) float64 {
+	if scale < minDensityScale {
+		return minDensityScale
+	}
+	if scale > maxDensityScale {
+		return maxDensityScale
+	}
+	return scale
+}
+
+// parseDensityScale parses a density_scale value, accepting either a number
+// or one of the named shortcuts ("compact", "comfortable")
+func parseDensityScale(value string) (float64, bool) {
+	switch value {
+	case "compact":
+		return DensityScaleCompact, true
+	case "comfortable":
+		return DensityScaleComfortable, true
+	}
+	scale, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return 0, false
 	}
+	return clampDensityScale(scale), true
 }
 
 // MotionTokens represents animation configuration
 type MotionTokens struct {
 	Level      string // "none", "subtle", "regular", "loud"
+	Mode       string // "light" or "dark" when tuned per mode, "" for mode-neutral
 	Durations  map[string]string
 	Amplitudes map[string]float64
 }
@@ -112,6 +281,23 @@ type MotionTokens struct {
 // ResolveDesignTokens resolves design tokens from query parameters
 // If auto_color_scheme is true, returns tokens that adapt to light/dark mode
 func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
+	return resolveDesignTokens(queryParams, nil, true)
+}
+
+// ResolveWithBase resolves design tokens from query parameters starting from
+// base instead of the built-in (or SetDefaultTokens) default, so precedence
+// is params, then theme, then base. It is a per-call SetDefaultTokens: base
+// is copied and applies even when a theme is given, so its fields survive
+// wherever the theme does not set them. A nil base behaves like
+// ResolveDesignTokens.
+func ResolveWithBase(queryParams map[string]string, base *DesignTokens) *DesignTokens {
+	return resolveDesignTokens(queryParams, base, true)
+}
+
+// resolveDesignTokens implements ResolveDesignTokens, starting from base when
+// given. checkAllowed applies the SetAllowedThemes allowlist; the built-in
+// theme helpers skip it.
+func resolveDesignTokens(queryParams map[string]string, base *DesignTokens, checkAllowed bool) *DesignTokens {
 	tokens := &DesignTokens{
 		Theme:      "default",
 		Color:      "#E5E7EB",
@@ -123,7 +309,33 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 		Density:    "comfortable",
 		Mode:       "dark",
 		Layout:     DefaultLayoutTokens(),
+
+		DensityScale: DensityScaleComfortable,
+	}
+
+	// A locked embed (locked=true) only honors theme and mode selection
+	queryParams = lockedQueryParams(queryParams)
+
+	// Canonicalize the theme name (separators, aliases) before any lookup
+	theme := NormalizeThemeName(queryParams["theme"])
+	if checkAllowed && !isThemeAllowed(theme) {
+		theme = ""
+	}
+
+	// Start from the caller's base, else the configured default when no
+	// theme is requested
+	fromBaseline := false
+	if base != nil {
+		tokens = base.clone()
+		fromBaseline = true
+	} else if theme == "" {
+		if configured := configuredDefaultTokens(); configured != nil {
+			tokens = configured
+			fromBaseline = true
+		}
 	}
+	// Only this call's theme param counts, not the one base was resolved from
+	tokens.themeExplicit = theme != ""
 
 	// Check for Radix UI theme tokens first
 	if accentColor, ok := queryParams["accentColor"]; ok && accentColor != "" {
@@ -140,6 +352,7 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 			// Try to parse as integer
 			if r, err := strconv.Atoi(radius); err == nil {
 				tokens.Radius = r
+				tokens.RadiusExplicit = true
 			}
 		}
 	}
@@ -147,74 +360,63 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 		tokens.RadixScaling = scaling
 	}
 
+	// Follow a client color-scheme preference (e.g. prefer=dark) when no
+	// explicit mode was requested and the chosen theme supports that mode.
+	// Single-mode and unknown themes keep their own mode.
+	preferApplied := false
+	if prefer, ok := queryParams["prefer"]; ok && (prefer == "light" || prefer == "dark") {
+		if mode, ok := queryParams["mode"]; !ok || mode == "" {
+			themeName, suffix := splitThemeMode(theme)
+			if themeName == "" {
+				themeName = "default"
+			}
+			supported, _ := themeSupportsMode(themeName, prefer)
+			if theme == "" && fromBaseline && tokens.RadixAccentColor == "" && tokens.RadixGrayColor == "" {
+				// A base or configured default only offers the modes it has
+				// variant colors for
+				supported = tokens.Mode == prefer || tokens.hasModeVariants(prefer)
+			}
+			if suffix == "" && supported {
+				tokens.Mode = prefer
+				preferApplied = true
+			}
+		}
+	}
+
+	// A requested theme or Radix palette replaces the base's colors, so drop
+	// the base's variants before the per-mode pass below would reapply them
+	if fromBaseline && (theme != "" || queryParams["accentColor"] != "" || queryParams["grayColor"] != "") {
+		tokens.clearColorVariants()
+	}
+
 	// Apply Radix theme if Radix tokens are present
 	if tokens.RadixAccentColor != "" || tokens.RadixGrayColor != "" {
 		applyRadixTheme(tokens)
 	}
 
 	// Apply theme if specified (and no Radix theme)
-	if theme, ok := queryParams["theme"]; ok && theme != "" && tokens.RadixAccentColor == "" {
+	densityBeforeTheme := tokens.Density
+	if theme != "" && tokens.RadixAccentColor == "" {
 		applyTheme(tokens, theme)
-	}
-
-	// Helper function to parse color (supports single or light/dark format)
-	parseColor := func(colorStr string) (string, string) {
-		if colorStr == "" {
-			return "", ""
-		}
-
-		// Check for dual color format: LIGHT/DARK
-		if strings.Contains(colorStr, "/") {
-			parts := strings.Split(colorStr, "/")
-			if len(parts) == 2 {
-				light := strings.TrimSpace(parts[0])
-				dark := strings.TrimSpace(parts[1])
-
-				// Validate both colors using the color package
-				// Query params never have # prefix (it's a URL fragment delimiter)
-				lightColor := light
-				if !strings.HasPrefix(lightColor, "#") {
-					lightColor = "#" + lightColor
-				}
-				darkColor := dark
-				if !strings.HasPrefix(darkColor, "#") {
-					darkColor = "#" + darkColor
-				}
-
-				// Validate parsing (but don't fail if invalid, just use as-is)
-				if _, err := color.ParseColor(lightColor); err == nil {
-					light = lightColor
-				} else {
-					light = lightColor // Use as-is even if parsing fails
-				}
-				if _, err := color.ParseColor(darkColor); err == nil {
-					dark = darkColor
-				} else {
-					dark = darkColor // Use as-is even if parsing fails
-				}
-
-				return light, dark
+	} else if preferApplied && tokens.RadixAccentColor == "" && tokens.RadixGrayColor == "" {
+		// No theme given: show the default palette in the preferred mode. A
+		// base or configured default keeps its palette and only switches
+		// variants.
+		if fromBaseline {
+			if tokens.Mode == "light" {
+				tokens = tokens.LightMode()
+			} else {
+				tokens = tokens.DarkMode()
 			}
+		} else {
+			applyTheme(tokens, "default")
 		}
-
-		// Single color format - use for both modes
-		// Query params never have # prefix
-		singleColor := colorStr
-		if !strings.HasPrefix(singleColor, "#") {
-			singleColor = "#" + singleColor
-		}
-
-		// Validate parsing (but don't fail if invalid, just use as-is)
-		if _, err := color.ParseColor(singleColor); err == nil {
-			return singleColor, singleColor
-		}
-		return singleColor, singleColor // Use as-is even if parsing fails
 	}
 
 	// Override with individual parameters
 	// Support both single color and light/dark variants (format: COLOR or LIGHT/DARK)
 	if color, ok := queryParams["color"]; ok && color != "" {
-		light, dark := parseColor(color)
+		light, dark := splitColorParam(color)
 		if light != "" {
 			tokens.ColorLight = light
 			tokens.ColorDark = dark
@@ -228,18 +430,14 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 	}
 	// Backwards compatibility: still support color_light and color_dark
 	if colorLight, ok := queryParams["color_light"]; ok && colorLight != "" {
-		if !strings.HasPrefix(colorLight, "#") {
-			colorLight = "#" + colorLight
-		}
+		colorLight = paramColor(colorLight)
 		tokens.ColorLight = colorLight
 		if tokens.Mode == "light" {
 			tokens.Color = colorLight
 		}
 	}
 	if colorDark, ok := queryParams["color_dark"]; ok && colorDark != "" {
-		if !strings.HasPrefix(colorDark, "#") {
-			colorDark = "#" + colorDark
-		}
+		colorDark = paramColor(colorDark)
 		tokens.ColorDark = colorDark
 		if tokens.Mode == "dark" {
 			tokens.Color = colorDark
@@ -247,7 +445,7 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 	}
 
 	if bg, ok := queryParams["background"]; ok && bg != "" {
-		light, dark := parseColor(bg)
+		light, dark := splitColorParam(bg)
 		if light != "" {
 			tokens.BackgroundLight = light
 			tokens.BackgroundDark = dark
@@ -261,18 +459,14 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 	}
 	// Backwards compatibility: still support background_light and background_dark
 	if bgLight, ok := queryParams["background_light"]; ok && bgLight != "" {
-		if !strings.HasPrefix(bgLight, "#") {
-			bgLight = "#" + bgLight
-		}
+		bgLight = paramColor(bgLight)
 		tokens.BackgroundLight = bgLight
 		if tokens.Mode == "light" {
 			tokens.Background = bgLight
 		}
 	}
 	if bgDark, ok := queryParams["background_dark"]; ok && bgDark != "" {
-		if !strings.HasPrefix(bgDark, "#") {
-			bgDark = "#" + bgDark
-		}
+		bgDark = paramColor(bgDark)
 		tokens.BackgroundDark = bgDark
 		if tokens.Mode == "dark" {
 			tokens.Background = bgDark
@@ -280,7 +474,7 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 	}
 
 	if accent, ok := queryParams["accent"]; ok && accent != "" {
-		light, dark := parseColor(accent)
+		light, dark := splitColorParam(accent)
 		if light != "" {
 			tokens.AccentLight = light
 			tokens.AccentDark = dark
@@ -292,44 +486,123 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 			}
 		}
 	}
+	// Brand color (single or LIGHT/DARK format), kept exactly as given
+	if brand, ok := queryParams["brand"]; ok && brand != "" {
+		light, dark := splitColorParam(brand)
+		if light != "" {
+			tokens.BrandLight = light
+			tokens.BrandDark = dark
+			if tokens.Mode == "light" {
+				tokens.Brand = light
+			} else {
+				tokens.Brand = dark
+			}
+		}
+	}
+
+	// Text selection overrides (single colors)
+	if selectionBg, ok := queryParams["selection_bg"]; ok && selectionBg != "" {
+		tokens.SelectionBackground, _ = splitColorParam(selectionBg)
+	}
+	if selectionFg, ok := queryParams["selection_fg"]; ok && selectionFg != "" {
+		tokens.SelectionColor, _ = splitColorParam(selectionFg)
+	}
+
+	// Link color override (single color)
+	if link, ok := queryParams["link"]; ok && link != "" {
+		tokens.Link, _ = splitColorParam(link)
+	}
+
 	// Backwards compatibility: still support accent_light and accent_dark
 	if accentLight, ok := queryParams["accent_light"]; ok && accentLight != "" {
-		if !strings.HasPrefix(accentLight, "#") {
-			accentLight = "#" + accentLight
-		}
+		accentLight = paramColor(accentLight)
 		tokens.AccentLight = accentLight
 		if tokens.Mode == "light" {
 			tokens.Accent = accentLight
 		}
 	}
 	if accentDark, ok := queryParams["accent_dark"]; ok && accentDark != "" {
-		if !strings.HasPrefix(accentDark, "#") {
-			accentDark = "#" + accentDark
-		}
+		accentDark = paramColor(accentDark)
 		tokens.AccentDark = accentDark
 		if tokens.Mode == "dark" {
 			tokens.Accent = accentDark
 		}
 	}
 
-	if density, ok := queryParams["density"]; ok && density != "" {
-		if density == "compact" || density == "comfortable" {
+	// Named density: the density param, else the theme's preferred density
+	density := queryParams["density"]
+	if density == "" && tokens.Density != densityBeforeTheme {
+		density = tokens.Density
+	}
+	if density != "" {
+		if isNamedDensity(density) {
 			tokens.Density = density
+			// Named density also tightens (or relaxes) typography
+			scale := DensityScaleComfortable
+			if density == "compact" {
+				scale = DensityScaleCompact
+			}
+			layout := *tokens.Layout
+			layout.LineHeight, layout.FontScale = typographyForDensityScale(scale)
+			tokens.Layout = &layout
+		}
+	}
+
+	// Spacing grid base unit (e.g. grid_base=5 for a 5px grid)
+	if gridBase, ok := queryParams["grid_base"]; ok && gridBase != "" {
+		if base, err := strconv.Atoi(gridBase); err == nil && base > 0 {
+			// Only spacing follows the grid; typography and card aspect are kept
+			layout := LayoutTokensFromBase(base)
+			layout.LineHeight = tokens.Layout.LineHeight
+			layout.FontScale = tokens.Layout.FontScale
+			layout.CardAspectRatio = tokens.Layout.CardAspectRatio
+			tokens.Layout = layout
+		}
+	}
+
+	// Continuous density scale for layout spacing (e.g. density_scale=0.85)
+	if densityScale, ok := queryParams["density_scale"]; ok && densityScale != "" {
+		if scale, ok := parseDensityScale(densityScale); ok {
+			tokens.DensityScale = scale
+			tokens.Layout = tokens.Layout.scaledSpacing(scale)
+			// Keep the named density in sync unless it was given explicitly
+			if _, ok := queryParams["density"]; !ok {
+				if scale < (DensityScaleCompact+DensityScaleComfortable)/2 {
+					tokens.Density = "compact"
+				} else {
+					tokens.Density = "comfortable"
+				}
+			}
+		}
+	}
+
+	// Card aspect ratio (width / height) for width-driven card heights
+	if cardAspect, ok := queryParams["card_aspect"]; ok && cardAspect != "" {
+		if aspect, err := strconv.ParseFloat(cardAspect, 64); err == nil && aspect > 0 {
+			layout := *tokens.Layout
+			layout.CardAspectRatio = aspect
+			tokens.Layout = &layout
 		}
 	}
 
 	// Handle mode - if not specified, try to infer from theme
 	if mode, ok := queryParams["mode"]; ok && mode != "" {
 		if mode == "light" || mode == "dark" {
-			tokens.Mode = mode
+			// Known single-mode themes (e.g. dracula) keep their own mode
+			themeName, _ := splitThemeMode(theme)
+			supported, known := themeSupportsMode(themeName, mode)
+			if supported || !known || tokens.RadixAccentColor != "" {
+				tokens.Mode = mode
+			}
 		}
 	} else {
 		// If theme was specified without explicit mode, check if it has a mode suffix
-		if theme, ok := queryParams["theme"]; ok && theme != "" {
-			if strings.HasSuffix(theme, "-light") {
-				tokens.Mode = "light"
-			} else if strings.HasSuffix(theme, "-dark") {
-				tokens.Mode = "dark"
+		// (single-mode themes keep their own mode)
+		if theme != "" {
+			if themeName, suffix := splitThemeMode(theme); suffix != "" {
+				if supported, known := themeSupportsMode(themeName, suffix); supported || !known {
+					tokens.Mode = suffix
+				}
 			}
 		}
 	}
@@ -337,15 +610,33 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 	// Apply Radix radius to numeric radius
 	if tokens.RadixRadius != "" {
 		tokens.Radius = radixRadiusToPixels(tokens.RadixRadius)
+		tokens.RadiusFull = tokens.RadixRadius == "full"
 	}
 
-	// Apply Radix scaling to padding and other spacing
+	// Apply Radix scaling to padding and other spacing. An explicit numeric
+	// radius is kept as given unless scale_radius=true; scale_radius=false
+	// also keeps Radix-token radii unscaled.
 	if tokens.RadixScaling != "" {
 		scale := radixScalingToFloat(tokens.RadixScaling)
-		tokens.Padding = int(float64(tokens.Padding) * scale)
-		if tokens.Radius > 0 {
-			tokens.Radius = int(float64(tokens.Radius) * scale)
+		tokens.Padding = roundScaled(float64(tokens.Padding) * scale)
+		scaleRadius := !tokens.RadiusExplicit
+		if v, ok := queryParams["scale_radius"]; ok && v != "" {
+			scaleRadius = v == "true"
 		}
+		// A full radius is a sentinel, not a size: scaling it would only
+		// risk overflow, so it is left alone. Scaled radii are capped.
+		if scaleRadius && tokens.Radius > 0 && !tokens.IsRadiusFull() {
+			scaled := roundScaled(float64(tokens.Radius) * scale)
+			if limit := maxScaledRadiusPx(); scaled > limit {
+				scaled = limit
+			}
+			tokens.Radius = scaled
+		}
+	}
+
+	// Keep the layout radius scale centered on the resolved radius
+	if !tokens.IsRadiusFull() && tokens.Layout.RadiusMD != tokens.Radius {
+		tokens.Layout = tokens.Layout.withRadiusMD(tokens.Radius)
 	}
 
 	// Apply light/dark variant colors based on current mode
@@ -361,6 +652,9 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 		if tokens.AccentLight != "" {
 			tokens.Accent = tokens.AccentLight
 		}
+		if tokens.BrandLight != "" {
+			tokens.Brand = tokens.BrandLight
+		}
 	} else if tokens.Mode == "dark" {
 		if tokens.ColorDark != "" {
 			tokens.Color = tokens.ColorDark
@@ -371,6 +665,93 @@ func ResolveDesignTokens(queryParams map[string]string) *DesignTokens {
 		if tokens.AccentDark != "" {
 			tokens.Accent = tokens.AccentDark
 		}
+		if tokens.BrandDark != "" {
+			tokens.Brand = tokens.BrandDark
+		}
+	}
+
+	// Solarized accent selection (yellow, orange, red, magenta, violet, blue, cyan, green)
+	if solarizedAccent, ok := queryParams["solarized_accent"]; ok && solarizedAccent != "" && tokens.Theme == "solarized" {
+		if accent, ok := SolarizedAccent(solarizedAccent); ok {
+			tokens.Accent = accent
+		}
+	}
+
+	// Blend mode for accent overlays drawn over imagery
+	if blend, ok := queryParams["accent_blend"]; ok && blend != "" {
+		if _, normalized := overlayAccent(tokens.Accent, blend); normalized != "normal" {
+			tokens.AccentBlend = normalized
+		}
+	}
+
+	// Accent-tinted backgrounds: tint=true uses the per-mode defaults,
+	// tint_light / tint_dark (0-1) set the strength for each mode
+	if hasTintParams(queryParams) {
+		light, dark := DefaultTintLight, DefaultTintDark
+		if v, err := strconv.ParseFloat(queryParams["tint_light"], 64); err == nil {
+			light = v
+		}
+		if v, err := strconv.ParseFloat(queryParams["tint_dark"], 64); err == nil {
+			dark = v
+		}
+		tokens.TintBackgroundForMode(light, dark)
+	}
+
+	// True-black OLED background (dark mode only)
+	if oled, ok := queryParams["oled"]; ok && oled == "true" {
+		tokens = tokens.ApplyOLED()
+	}
+
+	// Absolute accent lightness (e.g. accent_l=0.65), keeping hue and chroma
+	if accentL, ok := queryParams["accent_l"]; ok && accentL != "" {
+		if l, err := strconv.ParseFloat(accentL, 64); err == nil && !math.IsNaN(l) {
+			tokens.SetAccentLightness(l)
+		}
+	}
+
+	// Accent chroma floor (e.g. min_accent_chroma=0.08 against washed-out accents)
+	if minChroma, ok := queryParams["min_accent_chroma"]; ok && minChroma != "" {
+		if floor, err := strconv.ParseFloat(minChroma, 64); err == nil && floor > 0 {
+			tokens = tokens.EnsureAccentSaturation(floor)
+		}
+	}
+
+	// Chroma ceiling applied to every color (e.g. max_chroma=0.08 for a muted theme)
+	if maxChroma, ok := queryParams["max_chroma"]; ok && maxChroma != "" {
+		if limit, err := strconv.ParseFloat(maxChroma, 64); err == nil && limit >= 0 {
+			tokens.mapColors(func(c color.Color) color.Color {
+				return capChroma(c, limit)
+			})
+		}
+	}
+
+	// Glass/translucent background (e.g. glass=0.6&glass_blur=16)
+	if glass, ok := queryParams["glass"]; ok && glass != "" {
+		if opacity, err := strconv.ParseFloat(glass, 64); err == nil {
+			blur := DefaultGlassBlur
+			if glassBlur, ok := queryParams["glass_blur"]; ok && glassBlur != "" {
+				if b, err := strconv.Atoi(glassBlur); err == nil && b >= 0 {
+					blur = b
+				}
+			}
+			tokens.ApplyGlass(opacity, blur)
+		}
+	}
+
+	// Flat design: no shadows, gradients or backdrop blur
+	if flat, ok := queryParams["flat"]; ok && flat == "true" {
+		tokens = tokens.Flatten()
+	}
+
+	// Opaque colors and no backdrop blur for prefers-reduced-transparency
+	if reduced, ok := queryParams["reduced_transparency"]; ok && reduced == "true" {
+		tokens = tokens.ApplyReducedTransparency()
+	}
+
+	// Canonical hex case so equivalent params yield identical output
+	for _, field := range append(tokens.colorFields(), &tokens.Brand, &tokens.BrandLight, &tokens.BrandDark,
+		&tokens.SelectionBackground, &tokens.SelectionColor, &tokens.Link) {
+		*field = NormalizeHexCase(*field)
 	}
 
 	return tokens
@@ -392,7 +773,7 @@ func ResolveDesignTokensForBothModes(queryParams map[string]string) (*DesignToke
 	darkParams["mode"] = "dark"
 
 	// If theme is specified without mode suffix, apply it to both
-	if theme, ok := queryParams["theme"]; ok && theme != "" {
+	if theme := NormalizeThemeName(queryParams["theme"]); theme != "" {
 		if !strings.HasSuffix(theme, "-light") && !strings.HasSuffix(theme, "-dark") {
 			// Apply theme variant to both
 			lightParams["theme"] = theme + "-light"
@@ -406,43 +787,11 @@ func ResolveDesignTokensForBothModes(queryParams map[string]string) (*DesignToke
 
 	// Handle color variants: parse LIGHT/DARK format or use single color
 	parseColorForMode := func(colorStr string, mode string) string {
-		if colorStr == "" {
-			return ""
-		}
-		// Check for dual color format: LIGHT/DARK
-		if strings.Contains(colorStr, "/") {
-			parts := strings.Split(colorStr, "/")
-			if len(parts) == 2 {
-				var selectedColor string
-				if mode == "light" {
-					selectedColor = strings.TrimSpace(parts[0])
-				} else {
-					selectedColor = strings.TrimSpace(parts[1])
-				}
-
-				// Add # prefix if not present and validate with color package
-				if !strings.HasPrefix(selectedColor, "#") {
-					selectedColor = "#" + selectedColor
-				}
-
-				// Validate parsing (but don't fail if invalid, just use as-is)
-				if _, err := color.ParseColor(selectedColor); err == nil {
-					return selectedColor
-				}
-				return selectedColor // Use as-is even if parsing fails
-			}
-		}
-		// Single color - use for both modes
-		singleColor := colorStr
-		if !strings.HasPrefix(singleColor, "#") {
-			singleColor = "#" + singleColor
+		light, dark := splitColorParam(colorStr)
+		if mode == "light" {
+			return light
 		}
-
-		// Validate parsing (but don't fail if invalid, just use as-is)
-		if _, err := color.ParseColor(singleColor); err == nil {
-			return singleColor
-		}
-		return singleColor // Use as-is even if parsing fails
+		return dark
 	}
 
 	if color, ok := queryParams["color"]; ok && color != "" {
@@ -478,6 +827,17 @@ func ResolveDesignTokensForBothModes(queryParams map[string]string) (*DesignToke
 		}
 	}
 
+	if brand, ok := queryParams["brand"]; ok && brand != "" {
+		lightBrand := parseColorForMode(brand, "light")
+		darkBrand := parseColorForMode(brand, "dark")
+		if lightBrand != "" {
+			lightParams["brand"] = lightBrand
+		}
+		if darkBrand != "" {
+			darkParams["brand"] = darkBrand
+		}
+	}
+
 	lightTokens := ResolveDesignTokens(lightParams)
 	darkTokens := ResolveDesignTokens(darkParams)
 
@@ -535,6 +895,34 @@ func ResolveMotionTokens(queryParams map[string]string) *MotionTokens {
 	return tokens
 }
 
+// Per-mode ledBreathe multipliers: glows read fainter on dark backgrounds,
+// so they pulse brighter there and softer in light mode
+const (
+	darkModeGlowBoost  = 1.5
+	lightModeGlowScale = 0.75
+)
+
+// ResolveMotionTokensForMode resolves motion tokens like ResolveMotionTokens,
+// then tunes them for an appearance: "dark" brightens the ledBreathe glow
+// 1.5× and "light" softens it to 0.75×. Any other mode keeps the
+// mode-neutral profile ResolveMotionTokens returns.
+func ResolveMotionTokensForMode(queryParams map[string]string, mode string) *MotionTokens {
+	tokens := ResolveMotionTokens(queryParams)
+
+	var scale float64
+	switch mode {
+	case "dark":
+		scale = darkModeGlowBoost
+	case "light":
+		scale = lightModeGlowScale
+	default:
+		return tokens
+	}
+	tokens.Mode = mode
+	tokens.Amplitudes["ledBreathe"] = math.Round(tokens.Amplitudes["ledBreathe"]*scale*1000) / 1000
+	return tokens
+}
+
 // applyTheme applies a named theme to design tokens
 // Supports theme variants: "nord", "nord-light", "nord-dark", etc.
 func applyTheme(tokens *DesignTokens, theme string) {
@@ -543,149 +931,308 @@ func applyTheme(tokens *DesignTokens, theme string) {
 	mode := tokens.Mode // Use existing mode or default
 
 	// Check for explicit mode suffix (e.g., "nord-light", "nord-dark")
-	if strings.HasSuffix(theme, "-light") {
-		themeName = strings.TrimSuffix(theme, "-light")
-		mode = "light"
-	} else if strings.HasSuffix(theme, "-dark") {
-		themeName = strings.TrimSuffix(theme, "-dark")
-		mode = "dark"
-	}
-
-	// Theme definitions with light/dark variants
-	themes := map[string]map[string]map[string]string{
-		"nord": {
-			"light": {
-				"color":      "#2E3440",
-				"background": "#ECEFF4",
-				"accent":     "#5E81AC",
-			},
-			"dark": {
-				"color":      "#ECEFF4",
-				"background": "#2E3440",
-				"accent":     "#5E81AC",
-			},
-		},
-		"midnight": {
-			"light": {
-				"color":      "#1F2937",
-				"background": "#F9FAFB",
-				"accent":     "#2563EB",
-			},
-			"dark": {
-				"color":      "#E5E7EB",
-				"background": "#020617",
-				"accent":     "#1D4ED8",
-			},
-		},
-		"paper": {
-			"light": {
-				"color":      "#1F2937",
-				"background": "#F9FAFB",
-				"accent":     "#3B82F6",
-			},
-			"dark": {
-				"color":      "#E5E7EB",
-				"background": "#1F2937",
-				"accent":     "#60A5FA",
-			},
-		},
-		"wrapped": {
-			"light": {
-				"color":      "#1F2937",
-				"background": "#FDF2F8",
-				"accent":     "#EC4899",
-			},
-			"dark": {
-				"color":      "#EC4899",
-				"background": "#020617",
-				"accent":     "#7B58C9",
-			},
-		},
-		"default": {
-			"light": {
-				"color":      "#1F2937",
-				"background": "#FFFFFF",
-				"accent":     "#2563EB",
-			},
-			"dark": {
-				"color":      "#E5E7EB",
-				"background": "#020617",
-				"accent":     "#1D4ED8",
-			},
-		},
+	if name, suffix := splitThemeMode(theme); suffix != "" {
+		themeName = name
+		mode = suffix
 	}
 
 	// Apply theme colors based on mode
-	if themeMap, ok := themes[themeName]; ok {
+	if themeMap, ok := lookupTheme(themeName); ok {
+		tokens.Theme = themeName
 		if modeMap, ok := themeMap[mode]; ok {
 			tokens.Color = modeMap["color"]
 			tokens.Background = modeMap["background"]
 			tokens.Accent = modeMap["accent"]
 			tokens.Mode = mode
+			applyThemeDensity(tokens, modeMap)
 		} else {
-			// Fallback to dark if mode not found
-			if darkMap, ok := themeMap["dark"]; ok {
-				tokens.Color = darkMap["color"]
-				tokens.Background = darkMap["background"]
-				tokens.Accent = darkMap["accent"]
-				tokens.Mode = "dark"
+			// Fallback to dark if mode not found, then to light for
+			// light-only registered themes
+			for _, fallback := range []string{"dark", "light"} {
+				if fallbackMap, ok := themeMap[fallback]; ok {
+					tokens.Color = fallbackMap["color"]
+					tokens.Background = fallbackMap["background"]
+					tokens.Accent = fallbackMap["accent"]
+					tokens.Mode = fallback
+					applyThemeDensity(tokens, fallbackMap)
+					break
+				}
 			}
 		}
 
 		// Special handling for wrapped theme
-		if themeName == "wrapped" {
+		if themeName == "wrapped" && !tokens.RadiusExplicit {
 			tokens.Radius = 20
 		}
 	}
 }
 
-// applyRadixTheme applies Radix UI theme tokens
-func applyRadixTheme(tokens *DesignTokens) {
-	// Radix accent colors (approximate mappings)
-	accentColors := map[string]map[string]string{
-		"pink":   {"light": "#EC4899", "dark": "#F472B6"},
-		"blue":   {"light": "#3B82F6", "dark": "#60A5FA"},
-		"green":  {"light": "#10B981", "dark": "#34D399"},
-		"purple": {"light": "#8B5CF6", "dark": "#A78BFA"},
-		"red":    {"light": "#EF4444", "dark": "#F87171"},
-		"orange": {"light": "#F97316", "dark": "#FB923C"},
-		"yellow": {"light": "#EAB308", "dark": "#FCD34D"},
-		"cyan":   {"light": "#06B6D4", "dark": "#22D3EE"},
-		"violet": {"light": "#7C3AED", "dark": "#8B5CF6"},
-		"indigo": {"light": "#6366F1", "dark": "#818CF8"},
-	}
-
-	// Radix gray colors (approximate mappings)
-	grayColors := map[string]map[string]map[string]string{
-		"mauve": {
-			"light": {"bg": "#FDFCFD", "fg": "#1A1523", "border": "#E9E4ED"},
-			"dark":  {"bg": "#1A1523", "fg": "#EDE9FE", "border": "#2F2655"},
+// splitColorParam parses a color param in the single (COLOR) or dual
+// (LIGHT/DARK) format into its light and dark colors. Only a slash outside
+// parentheses separates the modes, so functional colors keep their alpha
+// separator: "hsl(200 50% 50% / 0.5)" is one color and
+// "hsl(200 50% 30% / 0.5)/hsl(200 50% 80% / 0.5)" is a pair.
+// Values with more than one top-level slash are kept whole.
+func splitColorParam(value string) (light, dark string) {
+	if value == "" {
+		return "", ""
+	}
+	if parts := splitTopLevel(value, '/'); len(parts) == 2 {
+		return paramColor(parts[0]), paramColor(parts[1])
+	}
+	single := paramColor(value)
+	return single, single
+}
+
+// splitTopLevel splits s on sep, ignoring separators nested inside (...)
+func splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// paramColor normalizes one color from a query param. Params never carry
+// the # of hex colors (it is a URL fragment delimiter), so it is added back;
+// functional syntax such as hsl(...) or color(display-p3 ...) passes through.
+func paramColor(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "#") || strings.Contains(value, "(") {
+		return value
+	}
+	return "#" + value
+}
+
+// isColorFunction reports whether a color param uses the CSS color()
+// function syntax, which must not be #-prefixed
+func isColorFunction(value string) bool {
+	v := strings.ToLower(strings.TrimSpace(value))
+	return strings.HasPrefix(v, "color(") && strings.HasSuffix(v, ")")
+}
+
+// applyThemeDensity applies a theme mode's preferred density ("density" key),
+// if it declares one. An explicit density param still wins in the resolver.
+func applyThemeDensity(tokens *DesignTokens, modeMap map[string]string) {
+	if density := modeMap["density"]; isNamedDensity(density) {
+		tokens.Density = density
+	}
+}
+
+// isNamedDensity reports whether density is "compact" or "comfortable"
+func isNamedDensity(density string) bool {
+	return density == "compact" || density == "comfortable"
+}
+
+// builtinThemes holds the built-in theme definitions with light/dark
+// variants. It is built once and shared, so it must be treated as read-only.
+var builtinThemes = map[string]map[string]map[string]string{
+	"nord": {
+		"light": {
+			"color":      "#2E3440",
+			"background": "#ECEFF4",
+			"accent":     "#5E81AC",
 		},
-		"slate": {
-			"light": {"bg": "#FBFCFD", "fg": "#1E293B", "border": "#E2E8F0"},
-			"dark":  {"bg": "#0F172A", "fg": "#F1F5F9", "border": "#1E293B"},
+		"dark": {
+			"color":      "#ECEFF4",
+			"background": "#2E3440",
+			"accent":     "#5E81AC",
 		},
-		"gray": {
-			"light": {"bg": "#FBFBFB", "fg": "#1C1C1F", "border": "#E4E4E7"},
-			"dark":  {"bg": "#111113", "fg": "#E4E4E7", "border": "#2A2A2B"},
+	},
+	"midnight": {
+		"light": {
+			"color":      "#1F2937",
+			"background": "#F9FAFB",
+			"accent":     "#2563EB",
 		},
-		"sage": {
-			"light": {"bg": "#FBFDFC", "fg": "#1C211C", "border": "#E8EDE8"},
-			"dark":  {"bg": "#141716", "fg": "#ECEDEC", "border": "#272D27"},
+		"dark": {
+			"color":      "#E5E7EB",
+			"background": "#020617",
+			"accent":     "#1D4ED8",
 		},
-		"olive": {
-			"light": {"bg": "#FCFDFC", "fg": "#1C211C", "border": "#E8EDE8"},
-			"dark":  {"bg": "#181B18", "fg": "#ECEDEC", "border": "#2A2E2A"},
+	},
+	"paper": {
+		"light": {
+			"color":      "#1F2937",
+			"background": "#F9FAFB",
+			"accent":     "#3B82F6",
 		},
-		"sand": {
-			"light": {"bg": "#FAF9F6", "fg": "#1C1C1A", "border": "#E8E6E1"},
-			"dark":  {"bg": "#161615", "fg": "#E8E6E1", "border": "#282826"},
+		"dark": {
+			"color":      "#E5E7EB",
+			"background": "#1F2937",
+			"accent":     "#60A5FA",
 		},
+	},
+	"wrapped": {
+		"light": {
+			"color":      "#1F2937",
+			"background": "#FDF2F8",
+			"accent":     "#EC4899",
+		},
+		"dark": {
+			"color":      "#EC4899",
+			"background": "#020617",
+			"accent":     "#7B58C9",
+		},
+	},
+	"solarized": {
+		"light": {
+			"color":      "#657B83",
+			"background": "#FDF6E3",
+			"accent":     "#268BD2",
+		},
+		"dark": {
+			"color":      "#839496",
+			"background": "#002B36",
+			"accent":     "#268BD2",
+		},
+	},
+	"terminal": {
+		"light": {
+			"color":      "#0C0C0C",
+			"background": "#F2F2F2",
+			"accent":     "#107C10",
+			"density":    "compact",
+		},
+		"dark": {
+			"color":      "#CCCCCC",
+			"background": "#0C0C0C",
+			"accent":     "#16C60C",
+			"density":    "compact",
+		},
+	},
+	"default": {
+		"light": {
+			"color":      "#1F2937",
+			"background": "#FFFFFF",
+			"accent":     "#2563EB",
+		},
+		"dark": {
+			"color":      "#E5E7EB",
+			"background": "#020617",
+			"accent":     "#1D4ED8",
+		},
+	},
+}
+
+// splitThemeMode splits a theme name into its base name and explicit mode
+// suffix, e.g. "nord-light" -> ("nord", "light"). Mode is empty when absent.
+func splitThemeMode(theme string) (string, string) {
+	if strings.HasSuffix(theme, "-light") {
+		return strings.TrimSuffix(theme, "-light"), "light"
+	}
+	if strings.HasSuffix(theme, "-dark") {
+		return strings.TrimSuffix(theme, "-dark"), "dark"
 	}
+	return theme, ""
+}
+
+// themeSupportsMode reports whether a built-in or registered theme defines the
+// given mode. known is false when themeName is not a known theme.
+func themeSupportsMode(themeName, mode string) (supported bool, known bool) {
+	themeMap, ok := lookupTheme(themeName)
+	if !ok {
+		return false, false
+	}
+	_, supported = themeMap[mode]
+	return supported, true
+}
 
+// SupportsMode reports whether the tokens' theme can be shown in the given
+// mode ("light" or "dark"), either because the theme defines that mode or
+// because explicit light/dark variant colors are set
+func (dt *DesignTokens) SupportsMode(mode string) bool {
+	if mode != "light" && mode != "dark" {
+		return false
+	}
+	themeName, _ := splitThemeMode(dt.Theme)
+	if supported, known := themeSupportsMode(themeName, mode); known {
+		return supported
+	}
+	if dt.Mode == mode {
+		return true
+	}
+
+	return dt.hasModeVariants(mode)
+}
+
+// clearColorVariants drops the light/dark variants of the text, background
+// and accent colors
+func (dt *DesignTokens) clearColorVariants() {
+	dt.ColorLight, dt.ColorDark = "", ""
+	dt.BackgroundLight, dt.BackgroundDark = "", ""
+	dt.AccentLight, dt.AccentDark = "", ""
+}
+
+// hasModeVariants reports whether explicit variant colors are set for mode
+func (dt *DesignTokens) hasModeVariants(mode string) bool {
+	if mode == "light" {
+		return dt.ColorLight != "" || dt.BackgroundLight != "" || dt.AccentLight != ""
+	}
+	return dt.ColorDark != "" || dt.BackgroundDark != "" || dt.AccentDark != ""
+}
+
+// radixAccentColors maps Radix accent names to approximate light/dark colors
+var radixAccentColors = map[string]map[string]string{
+	"pink":   {"light": "#EC4899", "dark": "#F472B6"},
+	"blue":   {"light": "#3B82F6", "dark": "#60A5FA"},
+	"green":  {"light": "#10B981", "dark": "#34D399"},
+	"purple": {"light": "#8B5CF6", "dark": "#A78BFA"},
+	"red":    {"light": "#EF4444", "dark": "#F87171"},
+	"orange": {"light": "#F97316", "dark": "#FB923C"},
+	"yellow": {"light": "#EAB308", "dark": "#FCD34D"},
+	"cyan":   {"light": "#06B6D4", "dark": "#22D3EE"},
+	"violet": {"light": "#7C3AED", "dark": "#8B5CF6"},
+	"indigo": {"light": "#6366F1", "dark": "#818CF8"},
+}
+
+// radixGrayColors maps Radix gray names to approximate per-mode background,
+// foreground and border colors
+var radixGrayColors = map[string]map[string]map[string]string{
+	"mauve": {
+		"light": {"bg": "#FDFCFD", "fg": "#1A1523", "border": "#E9E4ED"},
+		"dark":  {"bg": "#1A1523", "fg": "#EDE9FE", "border": "#2F2655"},
+	},
+	"slate": {
+		"light": {"bg": "#FBFCFD", "fg": "#1E293B", "border": "#E2E8F0"},
+		"dark":  {"bg": "#0F172A", "fg": "#F1F5F9", "border": "#1E293B"},
+	},
+	"gray": {
+		"light": {"bg": "#FBFBFB", "fg": "#1C1C1F", "border": "#E4E4E7"},
+		"dark":  {"bg": "#111113", "fg": "#E4E4E7", "border": "#2A2A2B"},
+	},
+	"sage": {
+		"light": {"bg": "#FBFDFC", "fg": "#1C211C", "border": "#E8EDE8"},
+		"dark":  {"bg": "#141716", "fg": "#ECEDEC", "border": "#272D27"},
+	},
+	"olive": {
+		"light": {"bg": "#FCFDFC", "fg": "#1C211C", "border": "#E8EDE8"},
+		"dark":  {"bg": "#181B18", "fg": "#ECEDEC", "border": "#2A2E2A"},
+	},
+	"sand": {
+		"light": {"bg": "#FAF9F6", "fg": "#1C1C1A", "border": "#E8E6E1"},
+		"dark":  {"bg": "#161615", "fg": "#E8E6E1", "border": "#282826"},
+	},
+}
+
+// applyRadixTheme applies Radix UI theme tokens
+func applyRadixTheme(tokens *DesignTokens) {
 	// Apply accent color
 	if tokens.RadixAccentColor != "" {
-		if colors, ok := accentColors[tokens.RadixAccentColor]; ok {
+		if colors, ok := radixAccentColors[tokens.RadixAccentColor]; ok {
 			if tokens.Mode == "light" {
 				tokens.Accent = colors["light"]
 			} else {
@@ -696,7 +1243,7 @@ func applyRadixTheme(tokens *DesignTokens) {
 
 	// Apply gray color
 	if tokens.RadixGrayColor != "" {
-		if grayMap, ok := grayColors[tokens.RadixGrayColor]; ok {
+		if grayMap, ok := radixGrayColors[tokens.RadixGrayColor]; ok {
 			if modeMap, ok := grayMap[tokens.Mode]; ok {
 				tokens.Background = modeMap["bg"]
 				tokens.Color = modeMap["fg"]
@@ -705,6 +1252,53 @@ func applyRadixTheme(tokens *DesignTokens) {
 	}
 }
 
+// radixGrayPairings lists the grays Radix recommends for each accent, after
+// the Radix Colors pairing guide. The neutral "gray" pairs with any accent.
+var radixGrayPairings = map[string][]string{
+	"pink":   {"mauve"},
+	"red":    {"mauve"},
+	"purple": {"mauve"},
+	"violet": {"mauve"},
+	"indigo": {"slate"},
+	"blue":   {"slate"},
+	"cyan":   {"slate"},
+	"green":  {"sage", "olive"},
+	"orange": {"sand"},
+	"yellow": {"sand"},
+}
+
+// radixGrayUndertones describes the tint of each tinted Radix gray
+var radixGrayUndertones = map[string]string{
+	"mauve": "purple",
+	"slate": "blue",
+	"sage":  "green",
+	"olive": "yellow-green",
+	"sand":  "warm yellow",
+}
+
+// RadixHarmonyWarning returns an advisory when the Radix accent and gray
+// clash, i.e. the gray's undertone is outside the accent's hue family (such as
+// a blue accent on sand), naming the recommended grays. It returns "" for
+// harmonious pairs, the neutral "gray", or when either color is unset or
+// unknown.
+func (dt *DesignTokens) RadixHarmonyWarning() string {
+	recommended, ok := radixGrayPairings[dt.RadixAccentColor]
+	if !ok {
+		return ""
+	}
+	undertone, ok := radixGrayUndertones[dt.RadixGrayColor]
+	if !ok {
+		return ""
+	}
+	for _, gray := range recommended {
+		if gray == dt.RadixGrayColor {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s accent may clash with the %s undertone of %s; Radix pairs %s with %s (or the neutral gray)",
+		dt.RadixAccentColor, undertone, dt.RadixGrayColor, dt.RadixAccentColor, strings.Join(recommended, " or "))
+}
+
 // radixRadiusToPixels converts Radix radius token to pixels
 func radixRadiusToPixels(radius string) int {
 	switch radius {
@@ -717,7 +1311,7 @@ func radixRadiusToPixels(radius string) int {
 	case "large":
 		return 16
 	case "full":
-		return 9999 // Very large for full rounding
+		return RadiusFullPx
 	default:
 		return 8 // Default to medium
 	}
@@ -735,17 +1329,3 @@ func radixScalingToFloat(scaling string) float64 {
 	}
 	return scale / 100.0
 }
-
-// ToCSS converts design tokens to CSS string for SVG
-func (dt *DesignTokens) ToCSS() string {
-	return fmt.Sprintf(`
-		:root {
-			--color: %s;
-			--background: %s;
-			--accent: %s;
-			--font-family: %s;
-			--radius: %dpx;
-			--padding: %dpx;
-		}
-	`, dt.Color, dt.Background, dt.Accent, dt.FontFamily, dt.Radius, dt.Padding)
-}
@@ -0,0 +1,271 @@
+package design
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResolveDesignTokensRadiusFullScaling(t *testing.T) {
+	tokens := ResolveDesignTokens(map[string]string{"radius": "full", "scaling": "110%"})
+	if tokens.Radius != RadiusFullPx {
+		t.Errorf("Radius = %d, want %d", tokens.Radius, RadiusFullPx)
+	}
+	if !tokens.RadiusFull {
+		t.Error("RadiusFull = false, want true")
+	}
+	if got, want := tokens.Padding, 18; got != want {
+		t.Errorf("Padding = %d, want %d", got, want)
+	}
+}
+
+func TestSetMaxScaledRadius(t *testing.T) {
+	SetMaxScaledRadius(30)
+	defer SetMaxScaledRadius(0)
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   int
+	}{
+		{"under cap", map[string]string{"radius": "20", "scale_radius": "true", "scaling": "110%"}, 22},
+		{"capped", map[string]string{"radius": "40", "scale_radius": "true", "scaling": "110%"}, 30},
+		{"unscaled radius not capped", map[string]string{"radius": "40", "scaling": "110%"}, 40},
+		{"full radius not capped", map[string]string{"radius": "full", "scaling": "110%"}, RadiusFullPx},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveDesignTokens(tt.params).Radius; got != tt.want {
+				t.Errorf("Radius = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetMaxScaledRadiusReset(t *testing.T) {
+	SetMaxScaledRadius(30)
+	SetMaxScaledRadius(0)
+	if got := maxScaledRadiusPx(); got != DefaultMaxScaledRadius {
+		t.Errorf("maxScaledRadiusPx() = %d after reset, want %d", got, DefaultMaxScaledRadius)
+	}
+
+	SetMaxScaledRadius(RadiusFullPx)
+	defer SetMaxScaledRadius(0)
+	if got := maxScaledRadiusPx(); got != DefaultMaxScaledRadius {
+		t.Errorf("maxScaledRadiusPx() = %d, want at most %d", got, DefaultMaxScaledRadius)
+	}
+}
+
+func TestSplitTopLevel(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{""}},
+		{"FF0000", []string{"FF0000"}},
+		{"FFFFFF/000000", []string{"FFFFFF", "000000"}},
+		{"hsl(200 50% 50% / 0.5)", []string{"hsl(200 50% 50% / 0.5)"}},
+		{"hsl(200 50% 30% / 0.5)/hsl(200 50% 80% / 0.5)", []string{"hsl(200 50% 30% / 0.5)", "hsl(200 50% 80% / 0.5)"}},
+		{"color-mix(in oklab, rgb(0 0 0 / 0.5), white)/000", []string{"color-mix(in oklab, rgb(0 0 0 / 0.5), white)", "000"}},
+		{"a/b(c/d)/e", []string{"a", "b(c/d)", "e"}},
+		{"a)/b", []string{"a)", "b"}},
+	}
+	for _, tt := range tests {
+		if got := splitTopLevel(tt.in, '/'); !slices.Equal(got, tt.want) {
+			t.Errorf("splitTopLevel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSplitColorParam(t *testing.T) {
+	tests := []struct {
+		in          string
+		light, dark string
+	}{
+		{"", "", ""},
+		{"FF0000", "#FF0000", "#FF0000"},
+		{"#FF0000", "#FF0000", "#FF0000"},
+		{"FFFFFF/000000", "#FFFFFF", "#000000"},
+		{" FFFFFF / 000000 ", "#FFFFFF", "#000000"},
+		{"hsl(200 50% 50% / 0.5)", "hsl(200 50% 50% / 0.5)", "hsl(200 50% 50% / 0.5)"},
+		{"hsl(200 50% 30% / 0.5)/hsl(200 50% 80% / 0.5)", "hsl(200 50% 30% / 0.5)", "hsl(200 50% 80% / 0.5)"},
+		{"rgb(0 0 0 / 50%)/FFFFFF", "rgb(0 0 0 / 50%)", "#FFFFFF"},
+		// More than one top-level slash is not a pair, so the value is kept whole
+		{"AAAAAA/BBBBBB/CCCCCC", "#AAAAAA/BBBBBB/CCCCCC", "#AAAAAA/BBBBBB/CCCCCC"},
+		{"hsl(0 0% 0% / 1)/hsl(0 0% 50% / 1)/hsl(0 0% 100% / 1)", "hsl(0 0% 0% / 1)/hsl(0 0% 50% / 1)/hsl(0 0% 100% / 1)", "hsl(0 0% 0% / 1)/hsl(0 0% 50% / 1)/hsl(0 0% 100% / 1)"},
+	}
+	for _, tt := range tests {
+		light, dark := splitColorParam(tt.in)
+		if light != tt.light || dark != tt.dark {
+			t.Errorf("splitColorParam(%q) = %q, %q, want %q, %q", tt.in, light, dark, tt.light, tt.dark)
+		}
+	}
+}
+
+func TestResolveHSLAlphaColors(t *testing.T) {
+	const (
+		single = "hsl(200 50% 50% / 0.5)"
+		light  = "hsl(200 50% 30% / 0.5)"
+		dark   = "hsl(200 50% 80% / 0.5)"
+	)
+
+	tests := []struct {
+		name                string
+		accent              string
+		wantLight, wantDark string
+	}{
+		{"single", single, single, single},
+		{"dual", light + "/" + dark, light, dark},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for mode, want := range map[string]string{"light": tt.wantLight, "dark": tt.wantDark} {
+				tokens := ResolveDesignTokens(map[string]string{"accent": tt.accent, "mode": mode})
+				if tokens.Accent != want {
+					t.Errorf("ResolveDesignTokens mode=%s: Accent = %q, want %q", mode, tokens.Accent, want)
+				}
+			}
+
+			lightTokens, darkTokens := ResolveDesignTokensForBothModes(map[string]string{"accent": tt.accent})
+			if lightTokens.Accent != tt.wantLight {
+				t.Errorf("ResolveDesignTokensForBothModes light: Accent = %q, want %q", lightTokens.Accent, tt.wantLight)
+			}
+			if darkTokens.Accent != tt.wantDark {
+				t.Errorf("ResolveDesignTokensForBothModes dark: Accent = %q, want %q", darkTokens.Accent, tt.wantDark)
+			}
+		})
+	}
+}
+
+func BenchmarkResolveDesignTokens(b *testing.B) {
+	benchmarks := []struct {
+		name   string
+		params map[string]string
+	}{
+		{"theme", map[string]string{"theme": "nord", "mode": "light"}},
+		{"radix", map[string]string{"accentColor": "blue", "grayColor": "slate", "radius": "large", "scaling": "110%"}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				ResolveDesignTokens(bm.params)
+			}
+		})
+	}
+}
+
+func TestResolveDesignTokensPrefer(t *testing.T) {
+	RegisterEditorThemes()
+
+	tests := []struct {
+		name           string
+		defaults       *DesignTokens
+		params         map[string]string
+		wantMode       string
+		wantBackground string
+	}{
+		{"no theme", nil, map[string]string{"prefer": "light"}, "light", builtinThemes["default"]["light"]["background"]},
+		{"theme", nil, map[string]string{"theme": "nord", "prefer": "light"}, "light", builtinThemes["nord"]["light"]["background"]},
+		{"mode wins", nil, map[string]string{"theme": "nord", "prefer": "light", "mode": "dark"}, "dark", builtinThemes["nord"]["dark"]["background"]},
+		{"dark suffix wins", nil, map[string]string{"theme": "nord-dark", "prefer": "light"}, "dark", builtinThemes["nord"]["dark"]["background"]},
+		{"light suffix wins", nil, map[string]string{"theme": "nord-light", "prefer": "dark"}, "light", builtinThemes["nord"]["light"]["background"]},
+		{"dark-only theme", nil, map[string]string{"theme": "dracula", "prefer": "light"}, "dark", "#282A36"},
+		{"unknown theme", nil, map[string]string{"theme": "rosepine", "prefer": "light"}, "dark", builtinThemes["default"]["dark"]["background"]},
+		{"single-mode configured default", NordTheme(), map[string]string{"prefer": "light"}, "dark", "#2E3440"},
+		{
+			"configured default with variants",
+			&DesignTokens{Theme: "default", Color: "#EEEEEE", Background: "#112233", Accent: "#FF6600", Mode: "dark", BackgroundLight: "#F0F4F8"},
+			map[string]string{"prefer": "light"},
+			"light", "#F0F4F8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaultTokens(tt.defaults)
+			defer SetDefaultTokens(nil)
+
+			tokens := ResolveDesignTokens(tt.params)
+			if tokens.Mode != tt.wantMode {
+				t.Errorf("Mode = %q, want %q", tokens.Mode, tt.wantMode)
+			}
+			if tokens.Background != tt.wantBackground {
+				t.Errorf("Background = %q, want %q", tokens.Background, tt.wantBackground)
+			}
+		})
+	}
+}
+
+func TestLegacyVariantParamsAcceptFunctionalColors(t *testing.T) {
+	const p3 = "color(display-p3 1 0 0)"
+	params := map[string]string{
+		"color_light":      p3,
+		"color_dark":       "ABCDEF",
+		"background_light": "hsl(0 0% 100%)",
+		"background_dark":  "#000000",
+		"accent_light":     p3,
+		"accent_dark":      "rgb(255 0 102)",
+	}
+	tokens := ResolveDesignTokens(params)
+
+	want := map[string]string{
+		"ColorLight":      p3,
+		"ColorDark":       "#ABCDEF",
+		"BackgroundLight": "hsl(0 0% 100%)",
+		"BackgroundDark":  "#000000",
+		"AccentLight":     p3,
+		"AccentDark":      "rgb(255 0 102)",
+	}
+	got := map[string]string{
+		"ColorLight":      tokens.ColorLight,
+		"ColorDark":       tokens.ColorDark,
+		"BackgroundLight": tokens.BackgroundLight,
+		"BackgroundDark":  tokens.BackgroundDark,
+		"AccentLight":     tokens.AccentLight,
+		"AccentDark":      tokens.AccentDark,
+	}
+	for field, w := range want {
+		if got[field] != w {
+			t.Errorf("%s = %q, want %q", field, got[field], w)
+		}
+	}
+}
+
+func TestResolveWithBaseThemeOverridesBaseColors(t *testing.T) {
+	base := ResolveDesignTokens(map[string]string{"theme": "nord", "accent": "ff0000"})
+	base.FontFamily = "Inter"
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		mode   string
+	}{
+		{"theme in the base's mode", map[string]string{"theme": "paper"}, "dark"},
+		{"theme with mode suffix", map[string]string{"theme": "paper-light"}, "light"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := ResolveWithBase(tt.params, base)
+			want := builtinThemes["paper"][tt.mode]
+			if tokens.Accent != want["accent"] {
+				t.Errorf("Accent = %q, want paper's %q", tokens.Accent, want["accent"])
+			}
+			if tokens.Background != want["background"] {
+				t.Errorf("Background = %q, want paper's %q", tokens.Background, want["background"])
+			}
+			if tokens.FontFamily != "Inter" {
+				t.Errorf("FontFamily = %q, want the base's %q", tokens.FontFamily, "Inter")
+			}
+		})
+	}
+
+	// Params still win over the theme
+	tokens := ResolveWithBase(map[string]string{"theme": "paper", "accent": "00ff00"}, base)
+	if tokens.Accent != "#00FF00" {
+		t.Errorf("Accent = %q, want the param's %q", tokens.Accent, "#00FF00")
+	}
+
+	// Without a theme the base's colors are kept
+	if got := ResolveWithBase(map[string]string{}, base).Accent; got != "#FF0000" {
+		t.Errorf("Accent without theme = %q, want the base's %q", got, "#FF0000")
+	}
+}
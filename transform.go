@@ -0,0 +1,45 @@
+package design
+
+// Transform is a named token transformation. Apply receives a copy of the
+// tokens it may modify in place or replace by returning new tokens; returning
+// nil keeps the (possibly modified) input.
+type Transform struct {
+	Name  string
+	Apply func(dt *DesignTokens) *DesignTokens
+}
+
+// TransformPipeline is an ordered sequence of transforms. Order matters:
+// tint-then-contrast and contrast-then-tint give different results, so the
+// pipeline makes composition explicit and deterministic.
+type TransformPipeline []Transform
+
+// Then returns a new pipeline with the named transform appended
+func (p TransformPipeline) Then(name string, apply func(dt *DesignTokens) *DesignTokens) TransformPipeline {
+	next := make(TransformPipeline, len(p), len(p)+1)
+	copy(next, p)
+	return append(next, Transform{Name: name, Apply: apply})
+}
+
+// Names returns the transform names in application order
+func (p TransformPipeline) Names() []string {
+	names := make([]string, len(p))
+	for i, t := range p {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// ApplyPipeline returns a copy of the tokens with each transform of the
+// pipeline applied in order. The receiver is never modified.
+func (dt *DesignTokens) ApplyPipeline(p TransformPipeline) *DesignTokens {
+	result := dt.clone()
+	for _, t := range p {
+		if t.Apply == nil {
+			continue
+		}
+		if next := t.Apply(result); next != nil && next != result {
+			result = next.clone()
+		}
+	}
+	return result
+}
@@ -0,0 +1,258 @@
+package design
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/SCKelemen/color"
+)
+
+// identicalThemeDistance is the ThemeDistance below which two themes are
+// considered perceptually identical
+const identicalThemeDistance = 0.02
+
+// ThemeDistance returns the mean perceptual distance between the Color,
+// Background and Accent of two themes (0 = identical). Unparseable colors
+// count as maximally distant.
+func ThemeDistance(a, b *DesignTokens) float64 {
+	pairs := [][2]string{
+		{a.Color, b.Color},
+		{a.Background, b.Background},
+		{a.Accent, b.Accent},
+	}
+
+	total := 0.0
+	for _, p := range pairs {
+		ca, errA := parseTokenColor(p[0])
+		cb, errB := parseTokenColor(p[1])
+		if errA != nil || errB != nil {
+			total += 1
+			continue
+		}
+		total += colorDistance(ca, cb)
+	}
+	return total / float64(len(pairs))
+}
+
+// ValidateThemeSet lints a set of themes destined for one stylesheet and
+// returns human-readable warnings for: themes whose ThemeSelector collides,
+// pairs of themes that are perceptually identical, and themes failing AA
+// contrast (4.5:1 for Color, 3:1 for the Accent as a UI color).
+// Warnings are ordered by theme key for stable output.
+func ValidateThemeSet(themes map[string]*DesignTokens) []string {
+	keys := make([]string, 0, len(themes))
+	for k, dt := range themes {
+		if dt != nil {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var warnings []string
+
+	selectors := map[string]string{}
+	for _, k := range keys {
+		selector := themes[k].ThemeSelector()
+		if other, ok := selectors[selector]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s: duplicate selector %s (also used by %s)", k, selector, other))
+			continue
+		}
+		selectors[selector] = k
+	}
+
+	for i, a := range keys {
+		for _, b := range keys[i+1:] {
+			if d := ThemeDistance(themes[a], themes[b]); d < identicalThemeDistance {
+				warnings = append(warnings, fmt.Sprintf("%s: perceptually identical to %s (distance %.3f)", a, b, d))
+			}
+		}
+	}
+
+	for _, k := range keys {
+		for _, failure := range contrastFailures(themes[k]) {
+			warnings = append(warnings, k+": "+failure)
+		}
+	}
+
+	return warnings
+}
+
+// contrastFailures describes each ContrastReport pair below AA: 4.5:1 for
+// text, 3:1 for the accent as a UI color
+func contrastFailures(dt *DesignTokens) []string {
+	var failures []string
+	for _, pair := range dt.ContrastReport().Pairs {
+		min := WCAGNormalTextAA
+		if pair.Name == "accent/background" {
+			min = WCAGLargeTextAA
+		}
+		if threshold := contrastThreshold(min); pair.Ratio < threshold {
+			if currentContrastAlgorithm() == ContrastAPCA {
+				failures = append(failures, fmt.Sprintf("%s contrast Lc %.0f is below Lc %.0f",
+					pair.Name, math.Floor(pair.Ratio), threshold))
+				continue
+			}
+			failures = append(failures, fmt.Sprintf("%s contrast %.2f:1 is below %.1f:1",
+				pair.Name, math.Floor(pair.Ratio*100)/100, min))
+		}
+	}
+	return failures
+}
+
+// Light/dark pair checks: maximum accent hue difference in degrees (OKLCH),
+// the chroma below which an accent counts as gray and has no hue to compare,
+// and the perceived lightness of mid-gray that backgrounds must straddle
+const (
+	pairHueTolerance    = 15.0
+	pairAchromaticLimit = 0.03
+	pairMidGray         = 0.5
+)
+
+// ArePairCompatible reports whether light and dark tokens form an accessible
+// adaptive pair: each passes AA contrast (as in ValidateThemeSet), their
+// accents share a hue within 15°, and the light background is lighter than
+// mid-gray while the dark one is darker. Reasons are returned for every
+// failed check.
+func ArePairCompatible(light, dark *DesignTokens) (bool, []string) {
+	var reasons []string
+	for _, failure := range contrastFailures(light) {
+		reasons = append(reasons, "light: "+failure)
+	}
+	for _, failure := range contrastFailures(dark) {
+		reasons = append(reasons, "dark: "+failure)
+	}
+
+	la, errL := parseTokenColor(light.Accent)
+	da, errD := parseTokenColor(dark.Accent)
+	if errL == nil && errD == nil {
+		lh, dh := color.ToOKLCH(la), color.ToOKLCH(da)
+		if lh.C >= pairAchromaticLimit && dh.C >= pairAchromaticLimit {
+			diff := math.Abs(lh.H - dh.H)
+			if diff > 180 {
+				diff = 360 - diff
+			}
+			if diff > pairHueTolerance {
+				reasons = append(reasons, fmt.Sprintf("accent hues differ by %.0f° (max %.0f°)", diff, pairHueTolerance))
+			}
+		}
+	}
+
+	if l := light.Luminosity(); l <= pairMidGray {
+		reasons = append(reasons, fmt.Sprintf("light background lightness %.2f is not above mid-gray", l))
+	}
+	if l := dark.Luminosity(); l >= pairMidGray {
+		reasons = append(reasons, fmt.Sprintf("dark background lightness %.2f is not below mid-gray", l))
+	}
+
+	return len(reasons) == 0, reasons
+}
+
+// CollidingColors returns the pairs of the theme's colors (by token name:
+// "color", "background", "accent" and "brand" when set) whose perceptual
+// distance is below minDistance, e.g. an accent equal to the background.
+// Distances use the same OKLAB metric as ThemeDistance; unparseable colors
+// are skipped.
+func (dt *DesignTokens) CollidingColors(minDistance float64) [][2]string {
+	fields := []tokenField{
+		{"color", dt.Color},
+		{"background", dt.Background},
+		{"accent", dt.Accent},
+	}
+	if dt.Brand != "" {
+		fields = append(fields, tokenField{"brand", dt.Brand})
+	}
+
+	var collisions [][2]string
+	for i, a := range fields {
+		ca, err := parseTokenColor(a.Value)
+		if err != nil {
+			continue
+		}
+		for _, b := range fields[i+1:] {
+			cb, err := parseTokenColor(b.Value)
+			if err != nil {
+				continue
+			}
+			if colorDistance(ca, cb) < minDistance {
+				collisions = append(collisions, [2]string{a.Key, b.Key})
+			}
+		}
+	}
+	return collisions
+}
+
+// maxNormalizedPaddingShare is the share of a card dimension that padding may
+// occupy after Normalize shrinks an oversized padding pair
+const maxNormalizedPaddingShare = 0.5
+
+// cardWidth returns the width of one card in the default grid: the grid
+// width minus the gaps, divided by the columns
+func (lt *LayoutTokens) cardWidth() float64 {
+	columns := lt.DefaultGridColumns
+	if columns <= 0 {
+		columns = 1
+	}
+	return (lt.DefaultGridWidth - float64(columns-1)*lt.DefaultGridGap) / float64(columns)
+}
+
+// layoutWarnings describes card paddings that leave no content area: the
+// horizontal pair against the default grid's card width, the vertical pair
+// against StatCardHeight
+func (lt *LayoutTokens) layoutWarnings() []string {
+	var warnings []string
+	if width := lt.cardWidth(); width > 0 {
+		if h := lt.CardPaddingLeft + lt.CardPaddingRight; float64(h) >= width {
+			warnings = append(warnings, fmt.Sprintf("card horizontal padding %dpx leaves no content width in a %.0fpx card", h, width))
+		}
+	}
+	if v := lt.CardPaddingTop + lt.CardPaddingBottom; v >= lt.StatCardHeight {
+		warnings = append(warnings, fmt.Sprintf("card vertical padding %dpx leaves no content height in a %dpx stat card", v, lt.StatCardHeight))
+	}
+	return warnings
+}
+
+// Normalize fixes card paddings that would produce negative content regions
+// and returns a warning for each problem found (see Validate). An oversized
+// padding pair is scaled down, keeping its proportions, to half the card
+// width or StatCardHeight. Valid layouts are left unchanged.
+func (lt *LayoutTokens) Normalize() []string {
+	warnings := lt.layoutWarnings()
+
+	// Shrink a pair that fills its dimension to maxNormalizedPaddingShare of it
+	shrink := func(a, b *int, dimension float64) {
+		total := float64(*a + *b)
+		if total <= 0 || total < dimension {
+			return
+		}
+		scale := dimension * maxNormalizedPaddingShare / total
+		*a = int(math.Floor(float64(*a) * scale))
+		*b = int(math.Floor(float64(*b) * scale))
+	}
+	if width := lt.cardWidth(); width > 0 {
+		shrink(&lt.CardPaddingLeft, &lt.CardPaddingRight, width)
+	}
+	shrink(&lt.CardPaddingTop, &lt.CardPaddingBottom, float64(lt.StatCardHeight))
+
+	return warnings
+}
+
+// Validate returns human-readable warnings for layout-breaking token
+// combinations: card paddings with no room left for content, a Padding wider
+// than half a card, and a (non-full) Radius larger than half the stat card
+// height. It reports only; use LayoutTokens.Normalize to fix paddings.
+func (dt *DesignTokens) Validate() []string {
+	layout := dt.Layout
+	if layout == nil {
+		layout = DefaultLayoutTokens()
+	}
+	warnings := layout.layoutWarnings()
+
+	if width := layout.cardWidth(); width > 0 && float64(2*dt.Padding) >= width {
+		warnings = append(warnings, fmt.Sprintf("padding %dpx leaves no content width in a %.0fpx card", dt.Padding, width))
+	}
+	if half := layout.StatCardHeight / 2; !dt.IsRadiusFull() && dt.Radius > half {
+		warnings = append(warnings, fmt.Sprintf("radius %dpx exceeds half the %dpx stat card height", dt.Radius, layout.StatCardHeight))
+	}
+	return warnings
+}